@@ -0,0 +1,284 @@
+package rexster_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// AdjacencyOptions filter the edges traversed by the adjacency methods
+// (GetVertexOutE, GetVertexOut, GetVertexOutCount, GetVertexOutIds,
+// and their In and Both counterparts). Those methods take at most one
+// AdjacencyOptions and return an error if given more. For example, to
+// get the first 10 "knows" edges out of vertex 1 with a weight (stored
+// as a float) over 0.5:
+//
+//	g.GetVertexOutE("1", AdjacencyOptions{
+//		Labels:     []string{"knows"},
+//		Properties: []PropertyFilter{{"weight", Greater, float32(0.5)}},
+//		Take:       10,
+//	})
+type AdjacencyOptions struct {
+	// Labels restricts the traversal to edges with any of these
+	// labels (Rexster's _label parameter).
+	Labels []string
+
+	// Limit, if positive, is the maximum number of edges to traverse
+	// (Rexster's _limit parameter).
+	Limit int
+
+	// Take, if positive, is the maximum number of results to return
+	// (Rexster's _take parameter).
+	Take int
+
+	// Properties restricts the traversal to edges whose properties
+	// match all of these filters (Rexster's _properties parameter).
+	Properties []PropertyFilter
+}
+
+// Compare is a comparison operator in a PropertyFilter.
+type Compare string
+
+const (
+	Equal        Compare = "="
+	NotEqual     Compare = "<>"
+	Greater      Compare = ">"
+	GreaterEqual Compare = ">="
+	Less         Compare = "<"
+	LessEqual    Compare = "<="
+)
+
+// A PropertyFilter matches edges whose property Key compares to Value
+// using Op. The Go type of Value determines the type Rexster compares
+// it as, which must match the type the property is stored as: int (or
+// int8, int16, or int32) is an integer, int64 a long, float32 a float,
+// float64 a double, bool a boolean, and string a string. Note that an
+// untyped constant like 0.5 is a float64, so it will not match a
+// property stored as a float. The adjacency methods return an error
+// for other types of Value (including named types, such as a type
+// whose underlying type is float32).
+//
+// Rexster has no way to escape the characters , [ ] and ( in filters,
+// so the adjacency methods also return an error if Key or a string
+// Value contains any of them.
+type PropertyFilter struct {
+	Key   string
+	Op    Compare
+	Value interface{}
+}
+
+// String returns f in Rexster's syntax (e.g., "[weight,>,(d,0.5)]").
+func (f PropertyFilter) String() string {
+	v, _ := typedValue(f.Value)
+	return "[" + f.Key + "," + string(f.Op) + "," + v + "]"
+}
+
+// typedValue returns v in Rexster's typed value syntax (e.g., "(i,3)"
+// for an int). If v's type is not supported, ok is false and s is v
+// formatted with %v.
+func typedValue(v interface{}) (s string, ok bool) {
+	var typ string
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case int, int32, int16, int8:
+		typ = "i"
+	case int64:
+		typ = "l"
+	case float32:
+		typ = "f"
+	case float64:
+		typ = "d"
+	case bool:
+		typ = "b"
+	default:
+		return fmt.Sprintf("%v", v), false
+	}
+	return fmt.Sprintf("(%s,%v)", typ, v), true
+}
+
+var errTooManyOptions = errors.New("rexster: at most one AdjacencyOptions may be given")
+
+// reserved are the characters that delimit lists and typed values in
+// Rexster's query parameters.
+const reserved = ",[]("
+
+// checkReserved returns an error if s contains a reserved character.
+func checkReserved(what, s string) error {
+	if strings.ContainsAny(s, reserved) {
+		return fmt.Errorf("rexster: %s %q contains one of the reserved characters %q", what, s, reserved)
+	}
+	return nil
+}
+
+// adjacencyURL returns the URL of the given subresource of the vertex
+// with the given id, filtered by opts.
+func (g Graph) adjacencyURL(id, subresource string, opts []AdjacencyOptions) (string, error) {
+	if len(opts) > 1 {
+		return "", errTooManyOptions
+	}
+	u := g.getVertexSubURL(id, subresource)
+	if len(opts) == 1 {
+		q, err := adjacencyQuery(opts[0])
+		if err != nil {
+			return "", err
+		}
+		if q != "" {
+			u += "?" + q
+		}
+	}
+	return u, nil
+}
+
+// adjacencyQuery returns the query string for o, or "" if there are no
+// filters.
+func adjacencyQuery(o AdjacencyOptions) (string, error) {
+	for _, label := range o.Labels {
+		if err := checkReserved("label", label); err != nil {
+			return "", err
+		}
+	}
+	for _, f := range o.Properties {
+		if err := checkReserved("property key", f.Key); err != nil {
+			return "", err
+		}
+		if _, ok := typedValue(f.Value); !ok {
+			return "", fmt.Errorf("rexster: property %q has unsupported value type %T", f.Key, f.Value)
+		}
+		if v, ok := f.Value.(string); ok {
+			if err := checkReserved("property value", v); err != nil {
+				return "", err
+			}
+		}
+	}
+	q := url.Values{}
+	switch len(o.Labels) {
+	case 0:
+	case 1:
+		q.Set("_label", o.Labels[0])
+	default:
+		q.Set("_label", "["+strings.Join(o.Labels, ",")+"]")
+	}
+	if o.Limit > 0 {
+		q.Set("_limit", strconv.Itoa(o.Limit))
+	}
+	if o.Take > 0 {
+		q.Set("_take", strconv.Itoa(o.Take))
+	}
+	if len(o.Properties) > 0 {
+		filters := make([]string, len(o.Properties))
+		for i, f := range o.Properties {
+			filters[i] = f.String()
+		}
+		q.Set("_properties", "["+strings.Join(filters, ",")+"]")
+	}
+	return q.Encode(), nil
+}
+
+// GetVertexBothCount returns the number of edges into and out of the
+// vertex with the given id, filtered by opts.
+func (g Graph) GetVertexBothCount(id string, opts ...AdjacencyOptions) (int64, error) {
+	return g.GetVertexBothCountContext(context.Background(), id, opts...)
+}
+
+// GetVertexBothCountContext is like GetVertexBothCount but uses ctx for the HTTP request.
+func (g Graph) GetVertexBothCountContext(ctx context.Context, id string, opts ...AdjacencyOptions) (int64, error) {
+	return g.count(ctx, "GetVertexBothCount", id, "bothCount", opts)
+}
+
+// GetVertexInCount returns the number of edges into the vertex with
+// the given id, filtered by opts.
+func (g Graph) GetVertexInCount(id string, opts ...AdjacencyOptions) (int64, error) {
+	return g.GetVertexInCountContext(context.Background(), id, opts...)
+}
+
+// GetVertexInCountContext is like GetVertexInCount but uses ctx for the HTTP request.
+func (g Graph) GetVertexInCountContext(ctx context.Context, id string, opts ...AdjacencyOptions) (int64, error) {
+	return g.count(ctx, "GetVertexInCount", id, "inCount", opts)
+}
+
+// GetVertexOutCount returns the number of edges out of the vertex with
+// the given id, filtered by opts.
+func (g Graph) GetVertexOutCount(id string, opts ...AdjacencyOptions) (int64, error) {
+	return g.GetVertexOutCountContext(context.Background(), id, opts...)
+}
+
+// GetVertexOutCountContext is like GetVertexOutCount but uses ctx for the HTTP request.
+func (g Graph) GetVertexOutCountContext(ctx context.Context, id string, opts ...AdjacencyOptions) (int64, error) {
+	return g.count(ctx, "GetVertexOutCount", id, "outCount", opts)
+}
+
+// GetVertexBothIds returns the IDs of the vertices adjacent to the
+// vertex with the given id (in either direction), filtered by opts.
+func (g Graph) GetVertexBothIds(id string, opts ...AdjacencyOptions) ([]string, error) {
+	return g.GetVertexBothIdsContext(context.Background(), id, opts...)
+}
+
+// GetVertexBothIdsContext is like GetVertexBothIds but uses ctx for the HTTP request.
+func (g Graph) GetVertexBothIdsContext(ctx context.Context, id string, opts ...AdjacencyOptions) ([]string, error) {
+	return g.ids(ctx, "GetVertexBothIds", id, "bothIds", opts)
+}
+
+// GetVertexInIds returns the IDs of the vertices at the tails of the
+// edges into the vertex with the given id, filtered by opts.
+func (g Graph) GetVertexInIds(id string, opts ...AdjacencyOptions) ([]string, error) {
+	return g.GetVertexInIdsContext(context.Background(), id, opts...)
+}
+
+// GetVertexInIdsContext is like GetVertexInIds but uses ctx for the HTTP request.
+func (g Graph) GetVertexInIdsContext(ctx context.Context, id string, opts ...AdjacencyOptions) ([]string, error) {
+	return g.ids(ctx, "GetVertexInIds", id, "inIds", opts)
+}
+
+// GetVertexOutIds returns the IDs of the vertices at the heads of the
+// edges out of the vertex with the given id, filtered by opts.
+func (g Graph) GetVertexOutIds(id string, opts ...AdjacencyOptions) ([]string, error) {
+	return g.GetVertexOutIdsContext(context.Background(), id, opts...)
+}
+
+// GetVertexOutIdsContext is like GetVertexOutIds but uses ctx for the HTTP request.
+func (g Graph) GetVertexOutIdsContext(ctx context.Context, id string, opts ...AdjacencyOptions) ([]string, error) {
+	return g.ids(ctx, "GetVertexOutIds", id, "outIds", opts)
+}
+
+func (g Graph) count(ctx context.Context, op, id, subresource string, opts []AdjacencyOptions) (int64, error) {
+	g.log(op, id)
+	var v struct {
+		TotalSize int64 `json:"totalSize"`
+	}
+	url, err := g.adjacencyURL(id, subresource, opts)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := g.do(ctx, &call{op: op, method: "GET", url: url, id: id, decode: decodeInto(&v)}); err != nil {
+		return 0, err
+	}
+	return v.TotalSize, nil
+}
+
+func (g Graph) ids(ctx context.Context, op, id, subresource string, opts []AdjacencyOptions) ([]string, error) {
+	g.log(op, id)
+	url, err := g.adjacencyURL(id, subresource, opts)
+	if err != nil {
+		return nil, err
+	}
+	var v struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if _, err := g.do(ctx, &call{op: op, method: "GET", url: url, id: id, decode: decodeInto(&v)}); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(v.Results))
+	for i, raw := range v.Results {
+		// Numeric IDs are kept verbatim (not decoded as float64), so
+		// they round-trip into GetVertex.
+		if err := json.Unmarshal(raw, &ids[i]); err != nil {
+			ids[i] = string(raw)
+		}
+	}
+	return ids, nil
+}
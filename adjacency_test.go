@@ -0,0 +1,123 @@
+package rexster_client
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAdjacencyQuery(t *testing.T) {
+	tests := []struct {
+		opts AdjacencyOptions
+		want string
+	}{
+		{AdjacencyOptions{}, ""},
+		{AdjacencyOptions{Labels: []string{"knows"}}, "_label=knows"},
+		{AdjacencyOptions{Labels: []string{"knows", "created"}}, "_label=%5Bknows%2Ccreated%5D"},
+		{AdjacencyOptions{Limit: 5, Take: 10}, "_limit=5&_take=10"},
+		{AdjacencyOptions{Properties: []PropertyFilter{{"weight", Greater, 0.5}}}, "_properties=%5B%5Bweight%2C%3E%2C%28d%2C0.5%29%5D%5D"},
+	}
+	for _, test := range tests {
+		got, err := adjacencyQuery(test.opts)
+		if err != nil {
+			t.Errorf("%+v: %v", test.opts, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%+v: want %q, got %q", test.opts, test.want, got)
+		}
+	}
+}
+
+func TestAdjacencyQuery_UnsupportedType(t *testing.T) {
+	type weight float32
+	for _, v := range []interface{}{uint(1), uint64(1), weight(0.5), struct{}{}, nil} {
+		opts := AdjacencyOptions{Properties: []PropertyFilter{{"weight", Greater, v}}}
+		if q, err := adjacencyQuery(opts); err == nil {
+			t.Errorf("%T: want error, got query %q", v, q)
+		}
+	}
+}
+
+func TestAdjacencyQuery_Reserved(t *testing.T) {
+	tests := []AdjacencyOptions{
+		{Labels: []string{"a,b"}},
+		{Labels: []string{"[knows]"}},
+		{Properties: []PropertyFilter{{"we,ight", Greater, 0.5}}},
+		{Properties: []PropertyFilter{{"name", Equal, "(i,3)"}}},
+		{Properties: []PropertyFilter{{"name", Equal, "a]b"}}},
+	}
+	for _, opts := range tests {
+		if q, err := adjacencyQuery(opts); err == nil {
+			t.Errorf("%+v: want error, got query %q", opts, q)
+		}
+	}
+}
+
+func TestPropertyFilter(t *testing.T) {
+	tests := []struct {
+		f    PropertyFilter
+		want string
+	}{
+		{PropertyFilter{"name", Equal, "marko"}, "[name,=,marko]"},
+		{PropertyFilter{"age", GreaterEqual, 29}, "[age,>=,(i,29)]"},
+		{PropertyFilter{"since", Less, int64(2008)}, "[since,<,(l,2008)]"},
+		{PropertyFilter{"weight", NotEqual, float32(0.5)}, "[weight,<>,(f,0.5)]"},
+		{PropertyFilter{"active", Equal, true}, "[active,=,(b,true)]"},
+	}
+	for _, test := range tests {
+		if got := test.f.String(); got != test.want {
+			t.Errorf("want %q, got %q", test.want, got)
+		}
+	}
+}
+
+func TestCountsAndIds_Request(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("_label"); got != "knows" {
+			t.Errorf("want _label=knows, got %q", got)
+		}
+		switch r.URL.Path {
+		case "/graphs/testgraph/vertices/1/outCount":
+			w.Write([]byte(`{"version":"2.4.0","totalSize":2,"queryTime":0.5}`))
+		case "/graphs/testgraph/vertices/1/outIds":
+			w.Write([]byte(`{"version":"2.4.0","results":["2",4,40000256,1000000],"totalSize":4}`))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	knows := AdjacencyOptions{Labels: []string{"knows"}}
+	n, err := g.GetVertexOutCount("1", knows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("want count 2, got %d", n)
+	}
+	ids, err := g.GetVertexOutIds("1", knows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2", "4", "40000256", "1000000"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("want ids %v, got %v", want, ids)
+	}
+}
+
+func TestAdjacency_TooManyOptions(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+	knows, created := AdjacencyOptions{Labels: []string{"knows"}}, AdjacencyOptions{Labels: []string{"created"}}
+	if _, err := g.GetVertexOutCount("1", knows, created); err != errTooManyOptions {
+		t.Errorf("want errTooManyOptions, got %v", err)
+	}
+	if _, err := g.GetVertexOutIds("1", knows, created); err != errTooManyOptions {
+		t.Errorf("want errTooManyOptions, got %v", err)
+	}
+	if _, err := g.GetVertexOutE("1", knows, created); err != errTooManyOptions {
+		t.Errorf("want errTooManyOptions, got %v", err)
+	}
+	if _, err := g.GetVertexOut("1", AdjacencyOptions{Labels: []string{"a,b"}}); err == nil {
+		t.Error("want error for reserved character in label")
+	}
+}
@@ -0,0 +1,192 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the server when the
+// Rexster server's circuit breaker is open.
+var ErrCircuitOpen = errors.New("rexster: circuit breaker is open")
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // requests are allowed
+	BreakerOpen                         // requests fail fast with ErrCircuitOpen
+	BreakerHalfOpen                     // a single probe request is allowed
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// A CircuitBreaker stops requests to an overloaded or failing Rexster
+// server. It opens after ConsecutiveFailures consecutive failures or
+// when the fraction of failed requests in the current Window reaches
+// FailureRate. While open, requests fail immediately with
+// ErrCircuitOpen. After CoolDown, it lets a single probe request
+// through (half-open); if the probe succeeds, it closes, and otherwise
+// it opens again.
+//
+// Only connection errors and HTTP 5xx responses count as failures;
+// other errors (e.g., ErrNotFound, ErrScriptFailed, or an error from
+// Rexster.Authorize) mean the server is healthy.
+//
+// A CircuitBreaker must not be copied after first use. Share it by
+// setting the same pointer in Rexster.Breaker.
+type CircuitBreaker struct {
+	ConsecutiveFailures int           // consecutive failures that open the breaker (0 to disable)
+	FailureRate         float64       // failure fraction in Window that opens the breaker (0 to disable)
+	MinRequests         int           // requests in Window needed before FailureRate applies (default 10)
+	Window              time.Duration // window for FailureRate (default 10s)
+	CoolDown            time.Duration // time spent open before probing (default 5s)
+
+	// OnStateChange, if set, is called (synchronously, without any
+	// locks held) when the breaker changes state.
+	OnStateChange func(from, to BreakerState)
+
+	mu          sync.Mutex
+	state       BreakerState
+	consecutive int       // consecutive failures
+	windowStart time.Time // start of the current FailureRate window
+	requests    int       // requests in the current window
+	failures    int       // failures in the current window
+	openedAt    time.Time
+	probing     bool // whether a half-open probe is in flight
+}
+
+// State returns the current state of the breaker.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.coolDown() {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// allow returns ErrCircuitOpen if a request may not be sent now.
+// Otherwise, it reports whether the request is the half-open probe,
+// whose outcome alone decides whether the breaker closes or reopens.
+func (b *CircuitBreaker) allow() (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	from := b.state
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.coolDown() {
+		b.state = BreakerHalfOpen
+	}
+	if b.state == BreakerHalfOpen {
+		if b.probing {
+			err = ErrCircuitOpen
+		} else {
+			b.probing, probe = true, true
+		}
+	} else if b.state == BreakerOpen {
+		err = ErrCircuitOpen
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.changed(from, to)
+	return probe, err
+}
+
+// record records the outcome of a request that allow permitted. The
+// probe argument is the value allow returned for it.
+func (b *CircuitBreaker) record(probe bool, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	from := b.state
+	if probe {
+		b.probing = false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// Says nothing about the server's health.
+		b.mu.Unlock()
+		return
+	}
+	now := time.Now()
+	if now.Sub(b.windowStart) >= b.window() {
+		b.windowStart, b.requests, b.failures = now, 0, 0
+	}
+	b.requests++
+	if isServerFailure(err) {
+		b.failures++
+		b.consecutive++
+		// Requests allowed before the breaker opened may finish
+		// while it is open or half-open; only the probe's outcome
+		// changes the state then.
+		if probe || (b.state == BreakerClosed && b.tripped()) {
+			b.state, b.openedAt = BreakerOpen, now
+		}
+	} else {
+		b.consecutive = 0
+		if probe {
+			b.state = BreakerClosed
+			b.windowStart, b.requests, b.failures = now, 0, 0
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.changed(from, to)
+}
+
+func (b *CircuitBreaker) tripped() bool {
+	if b.ConsecutiveFailures > 0 && b.consecutive >= b.ConsecutiveFailures {
+		return true
+	}
+	minRequests := b.MinRequests
+	if minRequests <= 0 {
+		minRequests = 10
+	}
+	return b.FailureRate > 0 && b.requests >= minRequests && float64(b.failures)/float64(b.requests) >= b.FailureRate
+}
+
+func (b *CircuitBreaker) changed(from, to BreakerState) {
+	if from != to && b.OnStateChange != nil {
+		b.OnStateChange(from, to)
+	}
+}
+
+func (b *CircuitBreaker) window() time.Duration {
+	if b.Window <= 0 {
+		return 10 * time.Second
+	}
+	return b.Window
+}
+
+func (b *CircuitBreaker) coolDown() time.Duration {
+	if b.CoolDown <= 0 {
+		return 5 * time.Second
+	}
+	return b.CoolDown
+}
+
+// isServerFailure reports whether err indicates that the server is
+// unavailable or failing (as opposed to, e.g., a missing vertex or a
+// Gremlin script that threw an exception).
+func isServerFailure(err error) bool {
+	if err == nil || errors.Is(err, ErrScriptFailed) {
+		return false
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.StatusCode >= 500
+	}
+	return isConnectionError(err)
+}
@@ -0,0 +1,137 @@
+package rexster_client
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var healthy, n int32
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		if atomic.LoadInt32(&healthy) == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	})
+	var changes []string
+	g.Server.Breaker = &CircuitBreaker{
+		ConsecutiveFailures: 2,
+		CoolDown:            20 * time.Millisecond,
+		OnStateChange: func(from, to BreakerState) {
+			changes = append(changes, from.String()+"->"+to.String())
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := g.GetVertex("1"); !errors.Is(err, ErrServerError) {
+			t.Fatalf("want ErrServerError, got %v", err)
+		}
+	}
+	if _, err := g.GetVertex("1"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("want ErrCircuitOpen, got %v", err)
+	}
+	if n != 2 {
+		t.Errorf("want 2 requests to reach the server, got %d", n)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	atomic.StoreInt32(&healthy, 1)
+	if _, err := g.GetVertex("1"); err != nil {
+		t.Fatalf("want probe to succeed, got %v", err)
+	}
+	if s := g.Server.Breaker.State(); s != BreakerClosed {
+		t.Errorf("want breaker closed, got %v", s)
+	}
+
+	want := []string{"closed->open", "open->half-open", "half-open->closed"}
+	if len(changes) != len(want) {
+		t.Fatalf("want state changes %v, got %v", want, changes)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("want state changes %v, got %v", want, changes)
+		}
+	}
+}
+
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	b := &CircuitBreaker{FailureRate: 0.5, MinRequests: 4}
+	failure := &Error{StatusCode: http.StatusServiceUnavailable}
+	for _, err := range []error{nil, failure, nil, failure} {
+		probe, allowErr := b.allow()
+		if allowErr != nil {
+			t.Fatal(allowErr)
+		}
+		b.record(probe, err)
+	}
+	if s := b.State(); s != BreakerOpen {
+		t.Errorf("want breaker open, got %v", s)
+	}
+}
+
+func TestCircuitBreaker_StaleResult(t *testing.T) {
+	b := &CircuitBreaker{ConsecutiveFailures: 1, CoolDown: 10 * time.Millisecond}
+	failure := &Error{StatusCode: http.StatusServiceUnavailable}
+
+	// a slow request is sent while the breaker is closed...
+	slowProbe, _ := b.allow()
+	// ...and another one fails, opening the breaker
+	probe, _ := b.allow()
+	b.record(probe, failure)
+	if s := b.State(); s != BreakerOpen {
+		t.Fatalf("want breaker open, got %v", s)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	probe, err := b.allow()
+	if err != nil || !probe {
+		t.Fatalf("want probe allowed, got probe=%v err=%v", probe, err)
+	}
+
+	// the slow request finishing must neither close the breaker nor
+	// let a second probe through
+	b.record(slowProbe, nil)
+	if s := b.State(); s != BreakerHalfOpen {
+		t.Errorf("want breaker still half-open, got %v", s)
+	}
+	if _, err := b.allow(); err != ErrCircuitOpen {
+		t.Errorf("want second probe rejected, got %v", err)
+	}
+
+	b.record(probe, nil)
+	if s := b.State(); s != BreakerClosed {
+		t.Errorf("want probe success to close breaker, got %v", s)
+	}
+}
+
+func TestCircuitBreaker_NotServerFailures(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"","error":"javax.script.ScriptException"}`))
+	})
+	g.Server.Breaker = &CircuitBreaker{ConsecutiveFailures: 2}
+	for i := 0; i < 2; i++ {
+		if _, err := g.Eval("g.bad()"); !errors.Is(err, ErrScriptFailed) {
+			t.Fatalf("want ErrScriptFailed, got %v", err)
+		}
+	}
+	if s := g.Server.Breaker.State(); s != BreakerClosed {
+		t.Errorf("want script failures to leave breaker closed, got %v", s)
+	}
+
+	authErr := errors.New("no token")
+	g.Server.Authorize = func(*http.Request) error { return authErr }
+	for i := 0; i < 2; i++ {
+		if _, err := g.GetVertex("1"); err != authErr {
+			t.Fatalf("want Authorize error, got %v", err)
+		}
+	}
+	if s := g.Server.Breaker.State(); s != BreakerClosed {
+		t.Errorf("want Authorize errors to leave breaker closed, got %v", s)
+	}
+}
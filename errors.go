@@ -0,0 +1,111 @@
+package rexster_client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors that an *Error returned by a Graph or Rexster
+// method matches (using errors.Is), depending on its HTTP status code.
+var (
+	// ErrBadRequest is matched by errors for HTTP 400 Bad Request.
+	ErrBadRequest = errors.New("rexster: bad request")
+
+	// ErrAuthFailed is matched by errors for HTTP 401 Unauthorized and
+	// 403 Forbidden.
+	ErrAuthFailed = errors.New("rexster: authentication failed")
+
+	// ErrNotFound is matched by errors for HTTP 404 Not Found, e.g.,
+	// when getting a vertex or edge that does not exist.
+	ErrNotFound = errors.New("rexster: not found")
+
+	// ErrScriptFailed is matched by errors for Gremlin scripts (see
+	// Graph.Eval) that Rexster failed to evaluate: HTTP 400 or 500
+	// responses with a JSON error body, and responses with "success":
+	// false.
+	ErrScriptFailed = errors.New("rexster: script failed")
+
+	// ErrServerError is matched by errors for HTTP 5xx responses.
+	ErrServerError = errors.New("rexster: server error")
+
+	// ErrInvalidResponse is matched by errors for successful (2xx)
+	// responses whose body could not be decoded.
+	ErrInvalidResponse = errors.New("rexster: invalid response")
+)
+
+// Error is an error response from the Rexster server.
+type Error struct {
+	StatusCode  int    // HTTP status code
+	Message     string // Rexster's "message" field
+	ServerError string // Rexster's "error" field (e.g., a Java exception)
+	Method      string // HTTP method of the request
+	URL         string // URL of the request
+
+	// Body is the start of the response body if it was not valid JSON
+	// (e.g., an HTML error page from a proxy).
+	Body string
+
+	// Err is the error decoding a successful (2xx) response, if any.
+	Err error
+
+	decoded      bool // whether the body was a JSON object
+	unsuccessful bool // whether the body had "success": false
+}
+
+// Error returns Rexster's message and error fields, or a description
+// of the HTTP status and body if both are empty.
+func (e *Error) Error() string {
+	msg := strings.TrimSpace(strings.Join([]string{e.Message, e.ServerError}, " "))
+	if msg == "" {
+		msg = fmt.Sprintf("rexster: HTTP %s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+		if e.Err != nil {
+			msg += ": invalid response: " + e.Err.Error()
+		}
+		if e.Body != "" {
+			msg += ": " + strings.TrimSpace(e.Body)
+		}
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Is reports whether e matches target, which should be one of the
+// sentinel errors in this package (ErrNotFound, etc.).
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrAuthFailed:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrScriptFailed:
+		if !e.isEval() {
+			return false
+		}
+		if e.unsuccessful {
+			return true
+		}
+		return e.decoded && (e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusInternalServerError)
+	case ErrServerError:
+		return e.StatusCode >= 500
+	case ErrInvalidResponse:
+		return e.Err != nil
+	}
+	return false
+}
+
+// isEval reports whether e is the response to a Gremlin script
+// evaluation request.
+func (e *Error) isEval() bool { return isEvalURL(e.URL) }
+
+// isEvalURL reports whether u is a Gremlin script evaluation URL.
+func isEvalURL(u string) bool {
+	if i := strings.Index(u, "?"); i != -1 {
+		u = u[:i]
+	}
+	return strings.HasSuffix(u, "/tp/gremlin")
+}
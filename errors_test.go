@@ -0,0 +1,51 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		status  int
+		path    string
+		want    []error
+		notWant []error
+	}{
+		{400, "/vertices/1", []error{ErrBadRequest}, []error{ErrNotFound, ErrServerError}},
+		{401, "/vertices/1", []error{ErrAuthFailed}, []error{ErrBadRequest}},
+		{403, "/vertices/1", []error{ErrAuthFailed}, nil},
+		{404, "/vertices/1", []error{ErrNotFound}, []error{ErrScriptFailed}},
+		{500, "/tp/gremlin", []error{ErrScriptFailed, ErrServerError}, []error{ErrNotFound}},
+		{500, "/vertices/1", []error{ErrServerError}, []error{ErrScriptFailed}},
+	}
+	for _, test := range tests {
+		g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(test.status)
+			w.Write([]byte(`{"message":"m","error":"e"}`))
+		})
+		_, err := g.get(context.Background(), "Test", g.baseURL().String()+test.path)
+		var e *Error
+		if !errors.As(err, &e) {
+			t.Fatalf("%d %s: want *Error, got %#v", test.status, test.path, err)
+		}
+		if e.StatusCode != test.status || e.Message != "m" || e.ServerError != "e" || e.Method != "GET" {
+			t.Errorf("%d %s: unexpected error fields %#v", test.status, test.path, e)
+		}
+		if msg := "m e"; e.Error() != msg {
+			t.Errorf("%d %s: want message %q, got %q", test.status, test.path, msg, e.Error())
+		}
+		for _, target := range test.want {
+			if !errors.Is(err, target) {
+				t.Errorf("%d %s: want error to match %v", test.status, test.path, target)
+			}
+		}
+		for _, target := range test.notWant {
+			if errors.Is(err, target) {
+				t.Errorf("%d %s: want error to not match %v", test.status, test.path, target)
+			}
+		}
+	}
+}
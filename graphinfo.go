@@ -0,0 +1,111 @@
+package rexster_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// ErrReadOnly is returned without contacting the server when a write
+// is attempted on a Graph whose Meta says it is read-only.
+var ErrReadOnly = errors.New("rexster: graph is read-only")
+
+// GraphInfo describes a graph served by Rexster (from GET
+// /graphs/{name}).
+type GraphInfo struct {
+	Name       string      `json:"name"`       // graph name
+	Graph      string      `json:"graph"`      // description (e.g., "tinkergraph[vertices:6 edges:6]")
+	Type       string      `json:"type"`       // Blueprints implementation class
+	ReadOnly   bool        `json:"readOnly"`   // whether the graph is read-only
+	Features   Features    `json:"features"`   // Blueprints features of the graph
+	Extensions []Extension `json:"extensions"` // installed Rexster extensions
+	Version    string      `json:"version"`    // Rexster version
+	UpTime     string      `json:"upTime"`     // server uptime
+}
+
+// Names of commonly used Blueprints features.
+const (
+	FeatureIgnoresSuppliedIDs      = "ignoresSuppliedIds"
+	FeatureSupportsTransactions    = "supportsTransactions"
+	FeatureSupportsVertexIteration = "supportsVertexIteration"
+	FeatureSupportsEdgeIteration   = "supportsEdgeIteration"
+	FeatureSupportsKeyIndices      = "supportsKeyIndices"
+	FeatureSupportsVertexIndex     = "supportsVertexIndex"
+	FeatureSupportsEdgeIndex       = "supportsEdgeIndex"
+	FeatureIsPersistent            = "isPersistent"
+)
+
+// Features are the Blueprints features of a graph (e.g.,
+// "supportsTransactions"), keyed by name.
+type Features map[string]bool
+
+// Has reports whether the graph has the named feature (e.g.,
+// FeatureSupportsTransactions).
+func (f Features) Has(name string) bool { return f[name] }
+
+// UnmarshalJSON decodes features, ignoring any that aren't booleans.
+func (f *Features) UnmarshalJSON(data []byte) error {
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*f = make(Features, len(m))
+	for k, v := range m {
+		if b, ok := v.(bool); ok {
+			(*f)[k] = b
+		}
+	}
+	return nil
+}
+
+// Extension is a Rexster extension installed on a graph (e.g., the
+// Gremlin extension, tp:gremlin).
+type Extension struct {
+	Op          string `json:"op"`          // HTTP method
+	Namespace   string `json:"namespace"`   // e.g., "tp"
+	Name        string `json:"name"`        // e.g., "gremlin"
+	Href        string `json:"href"`        // URL of the extension
+	Title       string `json:"title"`       // e.g., "tp:gremlin"
+	Description string `json:"description"` // human-readable description
+}
+
+// IgnoresSuppliedIDs reports whether the graph ignores the IDs that
+// clients supply for new elements (as, e.g., Neo4j does). It returns
+// false if gi is nil.
+func (gi *GraphInfo) IgnoresSuppliedIDs() bool {
+	return gi != nil && gi.Features.Has(FeatureIgnoresSuppliedIDs)
+}
+
+// Info returns information about the graph, including its features
+// and installed extensions.
+func (g Graph) Info() (*GraphInfo, error) {
+	return g.InfoContext(context.Background())
+}
+
+// InfoContext is like Info but uses ctx for the HTTP request.
+func (g Graph) InfoContext(ctx context.Context) (*GraphInfo, error) {
+	g.log("GraphInfo")
+	info := new(GraphInfo)
+	_, err := g.do(ctx, &call{op: "GraphInfo", method: "GET", url: g.baseURL().String(), decode: decodeInto(info)})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// WithInfo returns a copy of g whose Meta is set to the graph's
+// current Info, so that later operations are checked against the
+// graph's features.
+func (g Graph) WithInfo() (Graph, error) {
+	return g.WithInfoContext(context.Background())
+}
+
+// WithInfoContext is like WithInfo but uses ctx for the HTTP request.
+func (g Graph) WithInfoContext(ctx context.Context) (Graph, error) {
+	info, err := g.InfoContext(ctx)
+	if err != nil {
+		return g, err
+	}
+	g.Meta = info
+	return g, nil
+}
@@ -0,0 +1,33 @@
+package rexster_client
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGraph_WithInfo_ReadOnly(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			t.Errorf("unexpected %s request to read-only graph", r.Method)
+		}
+		w.Write([]byte(`{"name":"testgraph","readOnly":true,"features":{"ignoresSuppliedIds":true,"supportsTransactions":false,"maxSize":3},"extensions":[{"op":"GET","namespace":"tp","name":"gremlin"}]}`))
+	})
+	m := new(Metrics)
+	g.Server.Metrics = m
+	g, err := g.WithInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Snapshot()["GraphInfo"]; !ok {
+		t.Errorf("want GraphInfo op, got %v", m.Snapshot())
+	}
+	if !g.Meta.ReadOnly || !g.Meta.IgnoresSuppliedIDs() || g.Meta.Features.Has(FeatureSupportsTransactions) {
+		t.Errorf("unexpected graph info %+v", g.Meta)
+	}
+	if len(g.Meta.Extensions) != 1 || g.Meta.Extensions[0].Name != "gremlin" {
+		t.Errorf("unexpected extensions %+v", g.Meta.Extensions)
+	}
+	if _, err := g.CreateOrUpdateVertex(NewVertex("1", nil)); err != ErrReadOnly {
+		t.Errorf("want ErrReadOnly, got %v", err)
+	}
+}
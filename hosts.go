@@ -0,0 +1,157 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Balance is a strategy for choosing which host in a HostPool to send
+// a request to.
+type Balance int
+
+const (
+	RoundRobin    Balance = iota // cycle through the hosts in order
+	LeastInFlight                // pick the host with the fewest requests in flight
+)
+
+// A HostPool is a set of Rexster servers that serve the same graphs
+// (e.g., several Rexster instances in front of a shared Titan or Neo4j
+// HA backend). Set Rexster.Hosts to spread requests across them.
+//
+// A host whose request fails with a connection error is marked down
+// for DownTime, and the request is immediately sent to the next host.
+// Down hosts are skipped unless all hosts are down.
+//
+// A HostPool must not be copied after first use.
+type HostPool struct {
+	// URLs are the base URLs of the servers, in the same form as
+	// Rexster.BaseURL (e.g., http://rexster1:8182).
+	URLs []*url.URL
+
+	Balance  Balance
+	DownTime time.Duration // how long to skip a failed host (default 10s)
+
+	mu    sync.Mutex
+	next  int // round-robin position
+	hosts []hostState
+}
+
+type hostState struct {
+	inFlight  int
+	downUntil time.Time
+}
+
+// Down returns the URLs of the hosts that are currently marked down.
+func (p *HostPool) Down() []*url.URL {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var down []*url.URL
+	now := time.Now()
+	for i, h := range p.hosts {
+		if now.Before(h.downUntil) {
+			down = append(down, p.URLs[i])
+		}
+	}
+	return down
+}
+
+// pick chooses a host that isn't in tried and marks a request to it as
+// in flight. It returns false if all hosts have been tried.
+func (p *HostPool) pick(tried map[int]bool) (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.hosts) != len(p.URLs) {
+		p.hosts = make([]hostState, len(p.URLs))
+	}
+	now := time.Now()
+	best, bestUp := -1, false
+	for j := 0; j < len(p.URLs); j++ {
+		i := (p.next + j) % len(p.URLs)
+		if tried[i] {
+			continue
+		}
+		up := !now.Before(p.hosts[i].downUntil)
+		switch {
+		case best == -1, up && !bestUp:
+			best, bestUp = i, up
+		case up == bestUp && p.Balance == LeastInFlight && p.hosts[i].inFlight < p.hosts[best].inFlight:
+			best = i
+		}
+	}
+	if best == -1 {
+		return 0, false
+	}
+	p.next = (best + 1) % len(p.URLs)
+	p.hosts[best].inFlight++
+	return best, true
+}
+
+// done records the outcome of a request to host i.
+func (p *HostPool) done(i int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.hosts[i].inFlight--
+	if isConnectionError(err) {
+		downTime := p.DownTime
+		if downTime <= 0 {
+			downTime = 10 * time.Second
+		}
+		p.hosts[i].downUntil = time.Now().Add(downTime)
+	} else {
+		p.hosts[i].downUntil = time.Time{}
+	}
+}
+
+// roundTripHosts performs a single attempt of an HTTP request to one
+// of the hosts in r.Hosts, failing over to the next host on connection
+// errors. Writes and Gremlin evals (see call.replayable) only fail over
+// if the connection could not be made, since otherwise the first host
+// may already have applied them, unless r.Retry.RetryWrites is set. The c.url must be
+// relative to r.baseURL().
+func (r Rexster) roundTripHosts(ctx context.Context, c *call, body []byte) (resp *Response, err error) {
+	if r.Hosts == nil || len(r.Hosts.URLs) == 0 {
+		return r.roundTrip(ctx, c, c.url, body)
+	}
+	path := strings.TrimPrefix(c.url, r.baseURL().String())
+	tried := make(map[int]bool, len(r.Hosts.URLs))
+	for {
+		i, ok := r.Hosts.pick(tried)
+		if !ok {
+			return resp, err
+		}
+		resp, err = r.roundTrip(ctx, c, normalizeBaseURL(r.Hosts.URLs[i]).String()+path, body)
+		r.Hosts.done(i, err)
+		if !isConnectionError(err) || (!c.replayable() && !r.Retry.retryWrites() && !isDialError(err)) {
+			return resp, err
+		}
+		tried[i] = true
+	}
+}
+
+// isDialError reports whether err is a failure to establish a
+// connection, in which case the request was never sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isConnectionError reports whether err is a failure to connect to or
+// communicate with the server (as opposed to an error response from
+// the server or a canceled context).
+func isConnectionError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
+}
@@ -0,0 +1,114 @@
+package rexster_client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+)
+
+func TestHostPool_Failover(t *testing.T) {
+	var hits [2]int
+	var urls []*url.URL
+	for i := range hits {
+		i := i
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			hits[i]++
+			w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+		}))
+		defer s.Close()
+		u, _ := url.Parse(s.URL)
+		urls = append(urls, u)
+	}
+	// a host that refuses connections
+	dead := httptest.NewServer(http.NotFoundHandler())
+	deadURL, _ := url.Parse(dead.URL)
+	dead.Close()
+
+	pool := &HostPool{URLs: []*url.URL{deadURL, urls[0], urls[1]}}
+	g := Graph{Name: "testgraph", Server: Rexster{Hosts: pool}}
+	for i := 0; i < 4; i++ {
+		if _, err := g.GetVertex("1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if hits[0] != 2 || hits[1] != 2 {
+		t.Errorf("want requests balanced across live hosts, got %v", hits)
+	}
+	if down := pool.Down(); len(down) != 1 || down[0] != deadURL {
+		t.Errorf("want dead host marked down, got %v", down)
+	}
+
+	// writes fail over too if the connection could not be made
+	pool = &HostPool{URLs: []*url.URL{deadURL, urls[0]}}
+	g.Server.Hosts = pool
+	if _, err := g.CreateOrUpdateVertex(NewVertex("1", nil)); err != nil {
+		t.Errorf("want write to fail over from unreachable host, got %v", err)
+	}
+}
+
+func TestHostPool_LeastInFlight(t *testing.T) {
+	u1, _ := url.Parse("http://a")
+	u2, _ := url.Parse("http://b")
+	p := &HostPool{URLs: []*url.URL{u1, u2}, Balance: LeastInFlight}
+	i, _ := p.pick(nil)
+	j, _ := p.pick(nil)
+	if i == j {
+		t.Errorf("want different hosts, got %d and %d", i, j)
+	}
+	p.done(j, nil)
+	// host i still has a request in flight, so j is picked again
+	if k, _ := p.pick(nil); k != j {
+		t.Errorf("want least-in-flight host %d, got %d", j, k)
+	}
+}
+
+func TestHostPool_WriteNotReplayed(t *testing.T) {
+	var hits [2]int32
+	var urls []*url.URL
+	for i := range hits {
+		i := i
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&hits[i], 1)
+			// close the connection after reading the request, as if
+			// the server crashed while applying it
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err != nil {
+				t.Fatal(err)
+			}
+			conn.Close()
+		}))
+		defer s.Close()
+		u, _ := url.Parse(s.URL)
+		urls = append(urls, u)
+	}
+
+	g := Graph{Name: "testgraph", Server: Rexster{Hosts: &HostPool{URLs: urls}}}
+	if _, err := g.CreateVertex(nil); err == nil {
+		t.Error("want CreateVertex to fail")
+	}
+	if n := atomic.LoadInt32(&hits[0]) + atomic.LoadInt32(&hits[1]); n != 1 {
+		t.Errorf("want write sent to 1 host, got %d", n)
+	}
+
+	// nor is a Gremlin eval, whose script may change the graph
+	atomic.StoreInt32(&hits[0], 0)
+	atomic.StoreInt32(&hits[1], 0)
+	g.Server.Hosts = &HostPool{URLs: urls}
+	if _, err := g.Eval("g.addVertex()"); err == nil {
+		t.Error("want Eval to fail")
+	}
+	if n := atomic.LoadInt32(&hits[0]) + atomic.LoadInt32(&hits[1]); n != 1 {
+		t.Errorf("want eval sent to 1 host, got %d", n)
+	}
+
+	// with RetryWrites, the write fails over
+	atomic.StoreInt32(&hits[0], 0)
+	atomic.StoreInt32(&hits[1], 0)
+	g.Server.Retry = &RetryPolicy{RetryWrites: true}
+	g.CreateVertex(nil)
+	if atomic.LoadInt32(&hits[0]) != 1 || atomic.LoadInt32(&hits[1]) != 1 {
+		t.Errorf("want write sent to both hosts, got %d and %d", atomic.LoadInt32(&hits[0]), atomic.LoadInt32(&hits[1]))
+	}
+}
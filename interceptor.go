@@ -0,0 +1,38 @@
+package rexster_client
+
+import "net/http"
+
+// A Call is an HTTP request to a Rexster server as seen by an
+// Interceptor.
+type Call struct {
+	Op    string // operation name (e.g., "GetVertex" or "Batch")
+	Graph string // graph name, if any
+
+	// Request is the HTTP request to send. Interceptors may modify it
+	// (e.g., to add headers or rewrite the URL) or replace it before
+	// calling the next Handler.
+	Request *http.Request
+}
+
+// A Handler sends the request in a Call and returns the decoded
+// Response or an error.
+type Handler func(c *Call) (*Response, error)
+
+// An Interceptor wraps the sending of a request. It may inspect or
+// modify c before calling next, inspect the Response or error that
+// next returns, or return without calling next at all (e.g., to inject
+// a fault). Set Rexster.Interceptors to use interceptors.
+//
+// Interceptors run once per attempt, so a request that is retried (see
+// RetryPolicy) or fails over to another host (see HostPool) passes
+// through them more than once.
+type Interceptor func(c *Call, next Handler) (*Response, error)
+
+// intercept returns a Handler that runs r.Interceptors around h.
+func (r Rexster) intercept(h Handler) Handler {
+	for i := len(r.Interceptors) - 1; i >= 0; i-- {
+		ic, next := r.Interceptors[i], h
+		h = func(c *Call) (*Response, error) { return ic(c, next) }
+	}
+	return h
+}
@@ -0,0 +1,55 @@
+package rexster_client
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInterceptors(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Test") != "1" {
+			t.Errorf("want X-Test header set by interceptor")
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	})
+	var order []string
+	record := func(name string) Interceptor {
+		return func(c *Call, next Handler) (*Response, error) {
+			order = append(order, name+" "+c.Op)
+			resp, err := next(c)
+			order = append(order, name+" done")
+			return resp, err
+		}
+	}
+	setHeader := func(c *Call, next Handler) (*Response, error) {
+		c.Request.Header.Set("X-Test", "1")
+		return next(c)
+	}
+	g.Server.Interceptors = []Interceptor{record("a"), record("b"), setHeader}
+
+	r, err := g.GetVertex("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Vertex(); v == nil || v.Id() != "1" {
+		t.Errorf("want vertex 1, got %v", r)
+	}
+	if got, want := strings.Join(order, ", "), "a GetVertex, b GetVertex, b done, a done"; got != want {
+		t.Errorf("want order %q, got %q", want, got)
+	}
+}
+
+func TestInterceptors_Fault(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not reach the server")
+	})
+	fault := errors.New("injected")
+	g.Server.Interceptors = []Interceptor{func(c *Call, next Handler) (*Response, error) {
+		return nil, fault
+	}}
+	if _, err := g.Batch(nil); err != fault {
+		t.Errorf("want injected error, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package rexster_client
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// A Limiter limits the rate and concurrency of requests to a Rexster
+// server. Requests over the limit block until they are allowed or
+// their context is done. Set Rexster.ReadLimit, WriteLimit, or
+// EvalLimit to use one.
+//
+// A Limiter must not be copied after first use.
+type Limiter struct {
+	Rate        float64 // requests per second (0 for no rate limit)
+	Burst       int     // requests allowed at once above Rate (default max(1, Rate))
+	MaxInFlight int     // maximum concurrent requests (0 for no limit)
+
+	mu     sync.Mutex
+	tokens float64   // available tokens (negative if reserved by waiting requests)
+	last   time.Time // when tokens was last updated
+	sem    chan struct{}
+}
+
+// acquire blocks until a request is allowed and returns a function
+// that must be called when the request completes.
+func (l *Limiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if err := l.wait(ctx); err != nil {
+		return nil, err
+	}
+	l.mu.Lock()
+	if l.MaxInFlight > 0 && l.sem == nil {
+		l.sem = make(chan struct{}, l.MaxInFlight)
+	}
+	sem := l.sem
+	l.mu.Unlock()
+	if sem == nil {
+		return func() {}, nil
+	}
+	select {
+	case sem <- struct{}{}:
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// wait blocks until the rate limit allows a request.
+func (l *Limiter) wait(ctx context.Context) error {
+	if l.Rate <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	burst := float64(l.Burst)
+	if burst <= 0 {
+		burst = math.Max(1, l.Rate)
+	}
+	now := time.Now()
+	if l.last.IsZero() {
+		l.tokens = burst
+	} else {
+		l.tokens = math.Min(burst, l.tokens+now.Sub(l.last).Seconds()*l.Rate)
+	}
+	l.last = now
+	l.tokens-- // reserve a token
+	delay := time.Duration(-l.tokens / l.Rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++ // return the reserved token
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// limiter returns the Limiter for c's kind of request.
+func (r Rexster) limiter(c *call) *Limiter {
+	switch {
+	case isEvalURL(c.url):
+		return r.EvalLimit
+	case c.method == "GET":
+		return r.ReadLimit
+	default:
+		return r.WriteLimit
+	}
+}
@@ -0,0 +1,66 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimiter_MaxInFlight(t *testing.T) {
+	var inFlight, maxInFlight int32
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	})
+	g.Server.WriteLimit = &Limiter{MaxInFlight: 2}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := g.CreateOrUpdateVertex(NewVertex("1", nil)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if maxInFlight != 2 {
+		t.Errorf("want at most 2 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestLimiter_Rate(t *testing.T) {
+	l := &Limiter{Rate: 100, Burst: 1}
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := l.acquire(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		release()
+	}
+	if d := time.Since(start); d < 35*time.Millisecond {
+		t.Errorf("want 5 requests at 100/s to take at least 40ms, took %v", d)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	l = &Limiter{Rate: 1, Burst: 1}
+	l.acquire(ctx)
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want context.DeadlineExceeded, got %v", err)
+	}
+}
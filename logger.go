@@ -0,0 +1,75 @@
+package rexster_client
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"time"
+)
+
+// A Logger receives an Event for every HTTP request sent to a Rexster
+// server. Set Rexster.Logger to use one.
+type Logger interface {
+	LogEvent(ctx context.Context, e Event)
+}
+
+// An Event describes a single HTTP request to a Rexster server. If a
+// request is retried or fails over to another host, there is one Event
+// per attempt.
+type Event struct {
+	Op       string        // operation name (e.g., "GetVertex")
+	Graph    string        // graph name, if any
+	Method   string        // HTTP method
+	URL      string        // request URL
+	Status   int           // HTTP status code (0 if no response was received)
+	Duration time.Duration // time until the response was decoded
+	Err      error         // error, if the request failed
+}
+
+// NewSlogLogger returns a Logger that logs events to l (or, if l is
+// nil, to slog.Default()). Successful requests are logged at debug
+// level and failed requests at error level.
+func NewSlogLogger(l *slog.Logger) Logger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return slogLogger{l}
+}
+
+type slogLogger struct{ l *slog.Logger }
+
+func (s slogLogger) LogEvent(ctx context.Context, e Event) {
+	attrs := []slog.Attr{
+		slog.String("op", e.Op),
+		slog.String("graph", e.Graph),
+		slog.String("method", e.Method),
+		slog.String("url", e.URL),
+		slog.Int("status", e.Status),
+		slog.Duration("duration", e.Duration),
+	}
+	level, msg := slog.LevelDebug, "rexster request"
+	if e.Err != nil {
+		level, msg = slog.LevelError, "rexster request failed"
+		attrs = append(attrs, slog.Any("error", e.Err))
+	}
+	s.l.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logEvent reports a request to r.Logger and, if r.Debug is set and
+// the request failed, to the standard logger.
+func (r Rexster) logEvent(ctx context.Context, c *call, url string, status int, start time.Time, err error) {
+	if r.Debug && err != nil {
+		log.Printf("HTTP %s failed to %s: %v", c.method, url, err)
+	}
+	if r.Logger != nil {
+		r.Logger.LogEvent(ctx, Event{
+			Op:       c.op,
+			Graph:    c.graph,
+			Method:   c.method,
+			URL:      url,
+			Status:   status,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+	}
+}
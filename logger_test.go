@@ -0,0 +1,45 @@
+package rexster_client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type eventRecorder []Event
+
+func (r *eventRecorder) LogEvent(ctx context.Context, e Event) { *r = append(*r, e) }
+
+func TestLogger(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	var events eventRecorder
+	g.Server.Logger = &events
+	if _, err := g.GetVertex("1"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("want ErrNotFound, got %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("want 1 event, got %d", len(events))
+	}
+	e := events[0]
+	if e.Op != "GetVertex" || e.Graph != "testgraph" || e.Method != "GET" || e.Status != 404 || !strings.HasSuffix(e.URL, "/vertices/1") || !errors.Is(e.Err, ErrNotFound) {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+	l.LogEvent(context.Background(), Event{Op: "GetVertex", Graph: "g", Status: 500, Err: errors.New("boom")})
+	out := buf.String()
+	for _, want := range []string{"level=ERROR", "op=GetVertex", "graph=g", "status=500", "error=boom"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("want log output to contain %q, got %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"io"
+	"sync"
+	"time"
+)
+
+// Metrics collects per-operation statistics about requests to a
+// Rexster server. Set Rexster.Metrics to collect them. The zero value
+// is ready to use.
+type Metrics struct {
+	mu  sync.Mutex
+	ops map[string]*OpMetrics
+}
+
+// OpMetrics are the statistics for one operation (e.g., "GetVertex").
+type OpMetrics struct {
+	Count  int64            // completed operations (including failures)
+	Errors map[string]int64 // failed operations by error kind (e.g., "not_found")
+
+	Latency   Histogram // client-side latency in milliseconds, including retries
+	QueryTime Histogram // server-reported queryTime in milliseconds (successes only)
+
+	BytesSent     int64 // request body bytes sent
+	BytesReceived int64 // response body bytes received
+}
+
+// A Histogram counts observations in buckets.
+type Histogram struct {
+	Bounds []float64 // inclusive upper bounds of the buckets
+	Counts []int64   // observations per bucket; the last bucket is for values above all Bounds
+	Count  int64     // total observations
+	Sum    float64   // sum of all observations
+}
+
+// histogramBounds are the bucket bounds (in milliseconds) of all
+// Histograms collected by Metrics.
+var histogramBounds = []float64{1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+func (h *Histogram) observe(v float64) {
+	if h.Bounds == nil {
+		h.Bounds = histogramBounds
+		h.Counts = make([]int64, len(h.Bounds)+1)
+	}
+	i := 0
+	for i < len(h.Bounds) && v > h.Bounds[i] {
+		i++
+	}
+	h.Counts[i]++
+	h.Count++
+	h.Sum += v
+}
+
+// clone returns a deep copy of h, so that callers can't modify the
+// shared histogramBounds.
+func (h Histogram) clone() Histogram {
+	h.Bounds = append([]float64(nil), h.Bounds...)
+	h.Counts = append([]int64(nil), h.Counts...)
+	return h
+}
+
+// Snapshot returns a copy of the current statistics, keyed by
+// operation name.
+func (m *Metrics) Snapshot() map[string]OpMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snap := make(map[string]OpMetrics, len(m.ops))
+	for op, om := range m.ops {
+		c := *om
+		c.Errors = make(map[string]int64, len(om.Errors))
+		for k, v := range om.Errors {
+			c.Errors[k] = v
+		}
+		c.Latency = om.Latency.clone()
+		c.QueryTime = om.QueryTime.clone()
+		snap[op] = c
+	}
+	return snap
+}
+
+// Publish exports the statistics as an expvar variable with the given
+// name (e.g., "rexster"), served at /debug/vars. Like expvar.Publish,
+// it panics if name is already in use.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} { return m.Snapshot() }))
+}
+
+// record records a completed operation.
+func (m *Metrics) record(c *call, d time.Duration, resp *Response, err error) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ops == nil {
+		m.ops = make(map[string]*OpMetrics)
+	}
+	om, ok := m.ops[c.op]
+	if !ok {
+		om = &OpMetrics{Errors: make(map[string]int64)}
+		m.ops[c.op] = om
+	}
+	om.Count++
+	if err != nil {
+		om.Errors[errorKind(err)]++
+	}
+	om.Latency.observe(float64(d) / float64(time.Millisecond))
+	if resp != nil {
+		om.QueryTime.observe(resp.QueryTime)
+	}
+	om.BytesSent += c.bytesSent
+	om.BytesReceived += c.bytesReceived
+}
+
+// errorKind classifies err for OpMetrics.Errors.
+func errorKind(err error) string {
+	var e *Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrCircuitOpen):
+		return "circuit_open"
+	case !errors.As(err, &e):
+		if isConnectionError(err) {
+			return "connection"
+		}
+		return "other"
+	case e.Is(ErrAuthFailed):
+		return "auth"
+	case e.Is(ErrNotFound):
+		return "not_found"
+	case e.Is(ErrInvalidResponse):
+		return "invalid_response"
+	case e.Is(ErrScriptFailed):
+		return "script"
+	case e.Is(ErrBadRequest):
+		return "bad_request"
+	case e.Is(ErrServerError):
+		return "server"
+	}
+	return "http"
+}
+
+// countingReader counts the bytes read from an io.ReadCloser.
+type countingReader struct {
+	io.ReadCloser
+	n *int64
+}
+
+func (r countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	*r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,54 @@
+package rexster_client
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/graphs/testgraph/vertices/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"},"queryTime":3}`))
+	})
+	m := new(Metrics)
+	g.Server.Metrics = m
+
+	g.GetVertex("1")
+	g.GetVertex("missing")
+	g.CreateOrUpdateVertex(NewVertex("1", map[string]interface{}{"a": "b"}))
+
+	snap := m.Snapshot()
+	gv := snap["GetVertex"]
+	if gv.Count != 2 || gv.Errors["not_found"] != 1 || gv.Latency.Count != 2 {
+		t.Errorf("unexpected GetVertex metrics %+v", gv)
+	}
+	if gv.QueryTime.Count != 1 || gv.QueryTime.Sum != 3 || gv.QueryTime.Counts[2] != 1 {
+		t.Errorf("unexpected GetVertex queryTime histogram %+v", gv.QueryTime)
+	}
+	if gv.BytesReceived == 0 || gv.BytesSent != 0 {
+		t.Errorf("unexpected GetVertex byte counts %+v", gv)
+	}
+	if cv := snap["CreateOrUpdateVertex"]; cv.Count != 1 || cv.BytesSent == 0 {
+		t.Errorf("unexpected CreateOrUpdateVertex metrics %+v", cv)
+	}
+
+	// modifying a snapshot doesn't affect the collected histograms
+	gv.Latency.Bounds[0] = 999
+	if b := m.Snapshot()["GetVertex"].Latency.Bounds[0]; b != 1 || histogramBounds[0] != 1 {
+		t.Errorf("want bucket bounds unchanged by snapshot modification, got %v", b)
+	}
+
+	m.Publish("rexster_test")
+	var published map[string]OpMetrics
+	if err := json.Unmarshal([]byte(expvar.Get("rexster_test").String()), &published); err != nil {
+		t.Fatal(err)
+	}
+	if published["GetVertex"].Count != 2 {
+		t.Errorf("unexpected published metrics %+v", published)
+	}
+}
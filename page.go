@@ -0,0 +1,134 @@
+package rexster_client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used by AllVertices and AllEdges
+// when the given page size is not positive.
+const DefaultPageSize = 100
+
+// A Page is one page of the vertices or edges in a graph. Use its
+// Vertices or Edges method to get the elements.
+type Page struct {
+	*Response
+
+	Start int // offset of the first element on this page
+	Size  int // maximum number of elements per page
+}
+
+// A Pager iterates over the pages of vertices or edges in a graph. It
+// is returned by AllVertices and AllEdges.
+type Pager struct {
+	ctx   context.Context
+	g     Graph
+	edges bool // whether to page through edges (or vertices)
+	size  int
+	next  int // offset of the next page
+	page  *Page
+	err   error
+	done  bool
+}
+
+// AllVertices returns a Pager over the vertices in the graph, in pages
+// of at most pageSize vertices. Walk the graph like so:
+//
+//	p := g.AllVertices(100)
+//	for p.Next() {
+//		for _, v := range p.Page().Vertices() {
+//			// ...
+//		}
+//	}
+//	if err := p.Err(); err != nil {
+//		// handle error
+//	}
+//
+// The graph must support vertex iteration (see
+// FeatureSupportsVertexIteration).
+func (g Graph) AllVertices(pageSize int) *Pager {
+	return g.AllVerticesContext(context.Background(), pageSize)
+}
+
+// AllVerticesContext is like AllVertices but uses ctx for the HTTP requests.
+func (g Graph) AllVerticesContext(ctx context.Context, pageSize int) *Pager {
+	return g.pager(ctx, false, pageSize)
+}
+
+// AllEdges returns a Pager over the edges in the graph, in pages of at
+// most pageSize edges. See AllVertices.
+func (g Graph) AllEdges(pageSize int) *Pager {
+	return g.AllEdgesContext(context.Background(), pageSize)
+}
+
+// AllEdgesContext is like AllEdges but uses ctx for the HTTP requests.
+func (g Graph) AllEdgesContext(ctx context.Context, pageSize int) *Pager {
+	return g.pager(ctx, true, pageSize)
+}
+
+func (g Graph) pager(ctx context.Context, edges bool, size int) *Pager {
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	return &Pager{ctx: ctx, g: g, edges: edges, size: size}
+}
+
+// Next fetches the next page, which is then available from Page. It
+// returns false when there are no more pages or a request failed; call
+// Err to distinguish the two.
+func (p *Pager) Next() bool {
+	if p.done {
+		return false
+	}
+	p.page, p.err = p.g.page(p.ctx, p.edges, p.next, p.size)
+	if p.err != nil {
+		p.page, p.done = nil, true
+		return false
+	}
+	n := len(p.page.results())
+	if n < p.size {
+		// this is the last page
+		p.done = true
+	}
+	if n == 0 {
+		p.page = nil
+		return false
+	}
+	p.next += p.size
+	return true
+}
+
+// Page returns the page fetched by the last call to Next.
+func (p *Pager) Page() *Page { return p.page }
+
+// Err returns the error, if any, that stopped the iteration.
+func (p *Pager) Err() error { return p.err }
+
+func (p *Page) results() []interface{} {
+	results, _ := p.Results.([]interface{})
+	return results
+}
+
+func (g Graph) page(ctx context.Context, edges bool, start, size int) (*Page, error) {
+	op, u := "AllVertices", g.verticesURL()
+	if edges {
+		op, u = "AllEdges", g.edgesURL()
+	}
+	g.log(op, start, size)
+	u += "?" + pageQuery(start, size)
+	r, err := g.do(ctx, &call{op: op, method: "GET", url: u})
+	if err != nil {
+		return nil, err
+	}
+	return &Page{Response: r, Start: start, Size: size}, nil
+}
+
+// pageQuery returns the Rexster query parameters for the page of
+// elements with offsets in [start, start+size).
+func pageQuery(start, size int) string {
+	return url.Values{
+		"rexster.offset.start": {strconv.Itoa(start)},
+		"rexster.offset.end":   {strconv.Itoa(start + size)},
+	}.Encode()
+}
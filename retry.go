@@ -0,0 +1,119 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures automatic retries of failed requests to the
+// Rexster server. By default, only reads are retried; set RetryWrites
+// to also retry writes (POST, PUT, and DELETE requests) and Gremlin
+// evals, whose scripts may change the graph.
+//
+// The backoff before retry n (starting at 1) is InitialBackoff *
+// Multiplier^(n-1), capped at MaxBackoff, with jitter: the actual
+// delay is chosen uniformly between half the backoff and the full
+// backoff.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the
+	// first. Values less than 2 disable retries.
+	MaxAttempts int
+
+	InitialBackoff time.Duration // default 100ms
+	MaxBackoff     time.Duration // default 5s
+	Multiplier     float64       // default 2
+
+	// RetryStatusCodes is the list of HTTP status codes that are
+	// retried. If nil, 502, 503, and 504 are retried.
+	RetryStatusCodes []int
+
+	// Retryable, if set, decides whether an error is retryable,
+	// replacing the default rule (connection errors and
+	// RetryStatusCodes).
+	Retryable func(err error) bool
+
+	// RetryWrites enables retries of writes and Gremlin evals. Only
+	// set it if your writes and scripts are idempotent (e.g.,
+	// CreateOrUpdateVertex with a fixed ID).
+	RetryWrites bool
+}
+
+var defaultRetryStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// shouldRetry reports whether a request that failed with err on the
+// given attempt should be retried.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, c *call, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !c.replayable() && !p.retryWrites() {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	return p.isRetryable(err)
+}
+
+func (p *RetryPolicy) isRetryable(err error) bool {
+	var e *Error
+	if errors.As(err, &e) {
+		codes := p.RetryStatusCodes
+		if codes == nil {
+			codes = defaultRetryStatusCodes
+		}
+		for _, c := range codes {
+			if e.StatusCode == c {
+				return true
+			}
+		}
+		return false
+	}
+	return isConnectionError(err)
+}
+
+// retryWrites reports whether p allows writes and Gremlin evals to be
+// sent more than once.
+func (p *RetryPolicy) retryWrites() bool { return p != nil && p.RetryWrites }
+
+// replayable reports whether c can be sent more than once without
+// RetryWrites: a GET request other than a Gremlin eval, whose script
+// may change the graph.
+func (c *call) replayable() bool { return c.method == "GET" && !isEvalURL(c.url) }
+
+// backoff returns the delay before retrying after the given attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d, max, mult := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if d <= 0 {
+		d = 100 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 5 * time.Second
+	}
+	if mult < 1 {
+		mult = 2
+	}
+	for i := 1; i < attempt && d < max; i++ {
+		d = time.Duration(float64(d) * mult)
+	}
+	if d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// wait sleeps for the backoff after the given attempt, returning
+// early with ctx.Err() if ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, attempt int) error {
+	t := time.NewTimer(p.backoff(attempt))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,77 @@
+package rexster_client
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var n int32
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&n, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	})
+	g.Server.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	if _, err := g.GetVertex("1"); err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("want 3 attempts, got %d", n)
+	}
+
+	// writes are not retried unless RetryWrites is set
+	n = 0
+	_, err := g.CreateOrUpdateVertex(NewVertex("1", nil))
+	if !errors.Is(err, ErrServerError) || n != 1 {
+		t.Errorf("want 1 failed attempt, got %d (err %v)", n, err)
+	}
+	// nor are Gremlin evals, which may change the graph
+	n = 0
+	if _, err := g.Eval("g.addVertex()"); !errors.Is(err, ErrServerError) || n != 1 {
+		t.Errorf("want 1 failed eval attempt, got %d (err %v)", n, err)
+	}
+	n = 0
+	g.Server.Retry.RetryWrites = true
+	if _, err := g.CreateOrUpdateVertex(NewVertex("1", nil)); err != nil || n != 3 {
+		t.Errorf("want 3 attempts, got %d (err %v)", n, err)
+	}
+	n = 0
+	if _, err := g.Eval("g.addVertex()"); err != nil || n != 3 {
+		t.Errorf("want 3 eval attempts, got %d (err %v)", n, err)
+	}
+}
+
+func TestRetryPolicy_NotRetryable(t *testing.T) {
+	var n int32
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&n, 1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	g.Server.Retry = &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	if _, err := g.GetVertex("1"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("want ErrNotFound, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("want 1 attempt, got %d", n)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt, max := range []time.Duration{0, 100, 200, 400, 800, 1000, 1000} {
+		if attempt == 0 {
+			continue
+		}
+		max *= time.Millisecond
+		if d := p.backoff(attempt); d < max/2 || d > max {
+			t.Errorf("attempt %d: want backoff in [%v, %v], got %v", attempt, max/2, max, d)
+		}
+	}
+}
@@ -11,10 +11,16 @@
 // for more information. In the Rexster source dir, this means copying
 // batch-kibble-2.4.0-SNAPSHOT.jar to
 // ./rexster-server/target/rexster-server-2.4.0-SNAPSHOT-standalone/lib/.
+//
+// Each Graph method has a ...Context variant (e.g., GetVertexContext)
+// that takes a context.Context, which can be used to cancel the
+// request or set a deadline for it.
 package rexster_client
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,6 +29,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 // Rexster API
@@ -30,12 +37,90 @@ import (
 type Rexster struct {
 	Host     string // Rexster server host
 	RestPort uint16 // Rexster server REST API port (usually 8182)
-	Debug    bool   // Enable debug logging
+	Debug    bool   // Enable debug logging (to the standard logger)
+
+	// Logger, if set, receives a structured Event for every HTTP
+	// request sent to the server. See NewSlogLogger.
+	Logger Logger
+
+	// BaseURL is the full base URL of the Rexster server, including
+	// scheme, host, port, and any path prefix (e.g.,
+	// https://example.com/rexster). If set, Host and RestPort are
+	// ignored.
+	BaseURL *url.URL
+
+	// Hosts, if set, is a pool of Rexster servers serving the same
+	// graphs to balance requests across and fail over between. If set,
+	// Host, RestPort, and BaseURL are ignored.
+	Hosts *HostPool
+
+	// TLSConfig is the TLS configuration used for HTTPS connections
+	// (see HTTPClient for when it applies, and NewTLSConfig).
+	//
+	// A client is created and cached for each distinct TLSConfig
+	// pointer for the life of the process, so TLSConfig should be
+	// created once and shared, not rebuilt per request. To control
+	// the client's lifetime, set Transport to an *http.Transport
+	// whose TLSClientConfig is the config instead.
+	TLSConfig *tls.Config
+
+	// Username and Password, if either is set, are sent with every
+	// request using HTTP Basic authentication.
+	Username string
+	Password string
+
+	// Authorize, if set, is called on every request before it is sent
+	// and may add custom authentication headers (e.g., a bearer
+	// token). If it returns an error, the request is not sent.
+	Authorize func(req *http.Request) error
+
+	// HTTPClient is the HTTP client used to send requests. If nil, a
+	// client using Transport is used if it is set; otherwise, a client
+	// using TLSConfig if it is set; and otherwise http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Transport is the RoundTripper used to send requests when
+	// HTTPClient is nil.
+	Transport http.RoundTripper
+
+	// Retry, if set, is the policy for retrying failed requests. If
+	// nil, failed requests are not retried.
+	Retry *RetryPolicy
+
+	// Breaker, if set, is a circuit breaker that stops requests to the
+	// server while it is failing.
+	Breaker *CircuitBreaker
+
+	// ReadLimit, WriteLimit, and EvalLimit, if set, limit the rate and
+	// concurrency of reads (GET requests other than Gremlin evals),
+	// writes (POST, PUT, and DELETE requests), and Gremlin evals,
+	// respectively. Each limit applies to a whole operation, including
+	// its retries.
+	ReadLimit  *Limiter
+	WriteLimit *Limiter
+	EvalLimit  *Limiter
+
+	// Tracer, if set, starts a span for each operation.
+	Tracer Tracer
+
+	// Metrics, if set, collects statistics about all operations.
+	Metrics *Metrics
+
+	// Interceptors wrap every HTTP request sent to the server (see
+	// Interceptor). The first interceptor is the outermost: it sees
+	// the request first and the response last.
+	Interceptors []Interceptor
 }
 
 type Graph struct {
 	Name   string  // Name of graph served by Rexster
 	Server Rexster // The Rexster server that serves this graph
+
+	// Meta, if set, is the graph's metadata (see Info and WithInfo).
+	// Writes to a read-only graph fail with ErrReadOnly, and creating
+	// elements with IDs in a graph that ignores supplied IDs logs a
+	// warning (if Server.Debug is set).
+	Meta *GraphInfo
 }
 
 type Response struct {
@@ -51,73 +136,361 @@ type errorResponse struct {
 }
 
 func (g Graph) GetVertex(id string) (res *Response, err error) {
+	return g.GetVertexContext(context.Background(), id)
+}
+
+// GetVertexContext is like GetVertex but uses ctx for the HTTP request.
+func (g Graph) GetVertexContext(ctx context.Context, id string) (res *Response, err error) {
 	g.log("GetVertex", id)
 	url := g.getVertexURL(id)
-	return g.Server.get(url)
+	return g.do(ctx, &call{op: "GetVertex", method: "GET", url: url, id: id})
 }
 
 func (g Graph) QueryVertices(key, value string) (res *Response, err error) {
+	return g.QueryVerticesContext(context.Background(), key, value)
+}
+
+// QueryVerticesContext is like QueryVertices but uses ctx for the HTTP request.
+func (g Graph) QueryVerticesContext(ctx context.Context, key, value string) (res *Response, err error) {
 	g.log("QueryVertices", key, value)
 	url := g.queryVerticesURL(key, value)
-	return g.Server.get(url)
+	return g.get(ctx, "QueryVertices", url)
 }
 
 // QueryVerticesBatch retrieves all vertices in a key index with any
 // of the specified values. Requires the batch kibble.
 func (g Graph) QueryVerticesBatch(key string, values []string) (res *Response, err error) {
+	return g.QueryVerticesBatchContext(context.Background(), key, values)
+}
+
+// QueryVerticesBatchContext is like QueryVerticesBatch but uses ctx for the HTTP request.
+func (g Graph) QueryVerticesBatchContext(ctx context.Context, key string, values []string) (res *Response, err error) {
 	g.log("QueryVerticesBatch", key, len(values))
 	url := g.queryVerticesBatchURL(key, values)
-	return g.Server.get(url)
+	return g.get(ctx, "QueryVerticesBatch", url)
+}
+
+// GetVertexBothE gets the edges into and out of the vertex with the
+// given id, filtered by opts (see AdjacencyOptions).
+func (g Graph) GetVertexBothE(id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	return g.GetVertexBothEContext(context.Background(), id, opts...)
 }
 
-func (g Graph) GetVertexBothE(id string) (res *Response, err error) {
+// GetVertexBothEContext is like GetVertexBothE but uses ctx for the HTTP request.
+func (g Graph) GetVertexBothEContext(ctx context.Context, id string, opts ...AdjacencyOptions) (res *Response, err error) {
 	g.log("GetVertexBothE", id)
-	url := g.getVertexSubURL(id, "bothE")
-	return g.Server.get(url)
+	url, err := g.adjacencyURL(id, "bothE", opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.do(ctx, &call{op: "GetVertexBothE", method: "GET", url: url, id: id})
 }
 
-func (g Graph) GetVertexInE(id string) (res *Response, err error) {
+// GetVertexInE gets the edges into the vertex with the given id,
+// filtered by opts (see AdjacencyOptions).
+func (g Graph) GetVertexInE(id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	return g.GetVertexInEContext(context.Background(), id, opts...)
+}
+
+// GetVertexInEContext is like GetVertexInE but uses ctx for the HTTP request.
+func (g Graph) GetVertexInEContext(ctx context.Context, id string, opts ...AdjacencyOptions) (res *Response, err error) {
 	g.log("GetVertexInE", id)
-	url := g.getVertexSubURL(id, "inE")
-	return g.Server.get(url)
+	url, err := g.adjacencyURL(id, "inE", opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.do(ctx, &call{op: "GetVertexInE", method: "GET", url: url, id: id})
+}
+
+// GetVertexOutE gets the edges out of the vertex with the given id,
+// filtered by opts (see AdjacencyOptions).
+func (g Graph) GetVertexOutE(id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	return g.GetVertexOutEContext(context.Background(), id, opts...)
 }
 
-func (g Graph) GetVertexOutE(id string) (res *Response, err error) {
+// GetVertexOutEContext is like GetVertexOutE but uses ctx for the HTTP request.
+func (g Graph) GetVertexOutEContext(ctx context.Context, id string, opts ...AdjacencyOptions) (res *Response, err error) {
 	g.log("GetVertexOutE", id)
-	url := g.getVertexSubURL(id, "outE")
-	return g.Server.get(url)
+	url, err := g.adjacencyURL(id, "outE", opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.do(ctx, &call{op: "GetVertexOutE", method: "GET", url: url, id: id})
+}
+
+// GetVertexBoth gets the vertices adjacent to the vertex with the
+// given id (in either direction), filtered by opts (see
+// AdjacencyOptions). Use Response.Vertices to get them.
+func (g Graph) GetVertexBoth(id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	return g.GetVertexBothContext(context.Background(), id, opts...)
+}
+
+// GetVertexBothContext is like GetVertexBoth but uses ctx for the HTTP request.
+func (g Graph) GetVertexBothContext(ctx context.Context, id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	g.log("GetVertexBoth", id)
+	url, err := g.adjacencyURL(id, "both", opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.do(ctx, &call{op: "GetVertexBoth", method: "GET", url: url, id: id})
+}
+
+// GetVertexIn gets the vertices at the tails of the incoming edges
+// of the vertex with the given id, filtered by opts (see
+// AdjacencyOptions). Use Response.Vertices to get them.
+func (g Graph) GetVertexIn(id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	return g.GetVertexInContext(context.Background(), id, opts...)
+}
+
+// GetVertexInContext is like GetVertexIn but uses ctx for the HTTP request.
+func (g Graph) GetVertexInContext(ctx context.Context, id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	g.log("GetVertexIn", id)
+	url, err := g.adjacencyURL(id, "in", opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.do(ctx, &call{op: "GetVertexIn", method: "GET", url: url, id: id})
+}
+
+// GetVertexOut gets the vertices at the heads of the outgoing edges
+// of the vertex with the given id, filtered by opts (see
+// AdjacencyOptions). Use Response.Vertices to get them.
+func (g Graph) GetVertexOut(id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	return g.GetVertexOutContext(context.Background(), id, opts...)
+}
+
+// GetVertexOutContext is like GetVertexOut but uses ctx for the HTTP request.
+func (g Graph) GetVertexOutContext(ctx context.Context, id string, opts ...AdjacencyOptions) (res *Response, err error) {
+	g.log("GetVertexOut", id)
+	url, err := g.adjacencyURL(id, "out", opts)
+	if err != nil {
+		return nil, err
+	}
+	return g.do(ctx, &call{op: "GetVertexOut", method: "GET", url: url, id: id})
 }
 
 func (g Graph) GetEdge(id string) (res *Response, err error) {
+	return g.GetEdgeContext(context.Background(), id)
+}
+
+// GetEdgeContext is like GetEdge but uses ctx for the HTTP request.
+func (g Graph) GetEdgeContext(ctx context.Context, id string) (res *Response, err error) {
 	g.log("GetEdge", id)
 	url := g.getEdgeURL(id)
-	return g.Server.get(url)
+	return g.do(ctx, &call{op: "GetEdge", method: "GET", url: url, id: id})
 }
 
 func (g Graph) QueryEdges(key, value string) (res *Response, err error) {
+	return g.QueryEdgesContext(context.Background(), key, value)
+}
+
+// QueryEdgesContext is like QueryEdges but uses ctx for the HTTP request.
+func (g Graph) QueryEdgesContext(ctx context.Context, key, value string) (res *Response, err error) {
 	g.log("QueryEdges", key, value)
 	url := g.queryEdgesURL(key, value)
-	return g.Server.get(url)
+	return g.get(ctx, "QueryEdges", url)
 }
 
 // TODO(sqs): allow passing params to obviate interpolation/avoid
 // injection attacks
 func (g Graph) Eval(script string) (res *Response, err error) {
+	return g.EvalContext(context.Background(), script)
+}
+
+// EvalContext is like Eval but uses ctx for the HTTP request.
+func (g Graph) EvalContext(ctx context.Context, script string) (res *Response, err error) {
 	g.log("Eval", script)
 	url := g.evalURL(script)
-	return g.Server.get(url)
+	return g.do(ctx, &call{op: "Eval", method: "GET", url: url, script: script})
 }
 
+// CreateOrUpdateVertex creates the vertex v or, if it already exists,
+// merges v's properties into it: properties in v.Map are added or
+// overwritten, and other existing properties are kept. To make the
+// vertex have exactly v's properties, use ReplaceVertex.
 func (g Graph) CreateOrUpdateVertex(v *Vertex) (res *Response, err error) {
+	return g.CreateOrUpdateVertexContext(context.Background(), v)
+}
+
+// CreateOrUpdateVertexContext is like CreateOrUpdateVertex but uses ctx for the HTTP request.
+func (g Graph) CreateOrUpdateVertexContext(ctx context.Context, v *Vertex) (res *Response, err error) {
 	g.log("CreateOrUpdateVertex", v.Id())
+	if g.Meta.IgnoresSuppliedIDs() {
+		g.log("WARNING: graph ignores supplied IDs; vertex will not have ID", v.Id())
+	}
 	url := g.getVertexURL(v.Id())
-	return g.Server.send("POST", url, v.Map)
+	return g.do(ctx, &call{op: "CreateOrUpdateVertex", method: "POST", url: url, data: v.Map, id: v.Id()})
 }
 
+// CreateOrUpdateEdge creates the edge e or, if it already exists,
+// merges e's properties into it (see CreateOrUpdateVertex). To make
+// the edge have exactly e's properties, use ReplaceEdge.
 func (g Graph) CreateOrUpdateEdge(e *Edge) (res *Response, err error) {
+	return g.CreateOrUpdateEdgeContext(context.Background(), e)
+}
+
+// CreateOrUpdateEdgeContext is like CreateOrUpdateEdge but uses ctx for the HTTP request.
+func (g Graph) CreateOrUpdateEdgeContext(ctx context.Context, e *Edge) (res *Response, err error) {
 	g.log("CreateOrUpdateEdge", e)
+	if g.Meta.IgnoresSuppliedIDs() {
+		g.log("WARNING: graph ignores supplied IDs; edge will not have ID", e.Id())
+	}
 	url := g.getEdgeURL(e.Id())
-	return g.Server.send("POST", url, e.Map)
+	return g.do(ctx, &call{op: "CreateOrUpdateEdge", method: "POST", url: url, data: e.Map, id: e.Id()})
+}
+
+// CreateVertex creates a new vertex with the given properties and an
+// ID assigned by the server, and returns it. Unlike
+// CreateOrUpdateVertex, it works with graphs that ignore supplied IDs
+// (e.g., Neo4j).
+func (g Graph) CreateVertex(properties map[string]interface{}) (v *Vertex, err error) {
+	return g.CreateVertexContext(context.Background(), properties)
+}
+
+// CreateVertexContext is like CreateVertex but uses ctx for the HTTP request.
+func (g Graph) CreateVertexContext(ctx context.Context, properties map[string]interface{}) (v *Vertex, err error) {
+	g.log("CreateVertex")
+	if properties == nil {
+		properties = make(map[string]interface{})
+	}
+	r, err := g.do(ctx, &call{op: "CreateVertex", method: "POST", url: g.verticesURL(), data: properties})
+	if err != nil {
+		return nil, err
+	}
+	if v = r.Vertex(); v == nil {
+		return nil, errNoElement
+	}
+	return v, nil
+}
+
+// CreateEdge creates a new edge from outV to inV with the given label
+// and properties and an ID assigned by the server, and returns it (see
+// CreateVertex).
+func (g Graph) CreateEdge(outV, label, inV string, properties map[string]interface{}) (e *Edge, err error) {
+	return g.CreateEdgeContext(context.Background(), outV, label, inV, properties)
+}
+
+// CreateEdgeContext is like CreateEdge but uses ctx for the HTTP request.
+func (g Graph) CreateEdgeContext(ctx context.Context, outV, label, inV string, properties map[string]interface{}) (e *Edge, err error) {
+	g.log("CreateEdge", outV, label, inV)
+	data := make(map[string]interface{}, len(properties)+3)
+	for k, v := range properties {
+		data[k] = v
+	}
+	data["_outV"] = outV
+	data["_label"] = label
+	data["_inV"] = inV
+	r, err := g.do(ctx, &call{op: "CreateEdge", method: "POST", url: g.edgesURL(), data: data})
+	if err != nil {
+		return nil, err
+	}
+	if e = r.Edge(); e == nil {
+		return nil, errNoElement
+	}
+	return e, nil
+}
+
+// errNoElement is returned by CreateVertex and CreateEdge if the
+// server's response doesn't contain the created element.
+var errNoElement = errors.New("rexster: response does not contain the created element")
+
+// ReplaceVertex replaces all of the properties of the existing vertex
+// v with the properties in v.Map: properties not in v.Map are removed.
+// Unlike CreateOrUpdateVertex, which merges, it does not create the
+// vertex; if there is no such vertex, the error matches ErrNotFound.
+func (g Graph) ReplaceVertex(v *Vertex) (res *Response, err error) {
+	return g.ReplaceVertexContext(context.Background(), v)
+}
+
+// ReplaceVertexContext is like ReplaceVertex but uses ctx for the HTTP request.
+func (g Graph) ReplaceVertexContext(ctx context.Context, v *Vertex) (res *Response, err error) {
+	g.log("ReplaceVertex", v.Id())
+	url := g.getVertexURL(v.Id())
+	return g.do(ctx, &call{op: "ReplaceVertex", method: "PUT", url: url, data: v.Map, id: v.Id()})
+}
+
+// ReplaceEdge replaces all of the properties of the existing edge e
+// with the properties in e.Map (see ReplaceVertex).
+func (g Graph) ReplaceEdge(e *Edge) (res *Response, err error) {
+	return g.ReplaceEdgeContext(context.Background(), e)
+}
+
+// ReplaceEdgeContext is like ReplaceEdge but uses ctx for the HTTP request.
+func (g Graph) ReplaceEdgeContext(ctx context.Context, e *Edge) (res *Response, err error) {
+	g.log("ReplaceEdge", e.Id())
+	url := g.getEdgeURL(e.Id())
+	return g.do(ctx, &call{op: "ReplaceEdge", method: "PUT", url: url, data: e.Map, id: e.Id()})
+}
+
+// DeleteVertex deletes the vertex with the given id (and its edges).
+// If there is no such vertex, the error matches ErrNotFound.
+func (g Graph) DeleteVertex(id string) (res *Response, err error) {
+	return g.DeleteVertexContext(context.Background(), id)
+}
+
+// DeleteVertexContext is like DeleteVertex but uses ctx for the HTTP request.
+func (g Graph) DeleteVertexContext(ctx context.Context, id string) (res *Response, err error) {
+	g.log("DeleteVertex", id)
+	url := g.getVertexURL(id)
+	return g.do(ctx, &call{op: "DeleteVertex", method: "DELETE", url: url, id: id})
+}
+
+// DeleteEdge deletes the edge with the given id. If there is no such
+// edge, the error matches ErrNotFound.
+func (g Graph) DeleteEdge(id string) (res *Response, err error) {
+	return g.DeleteEdgeContext(context.Background(), id)
+}
+
+// DeleteEdgeContext is like DeleteEdge but uses ctx for the HTTP request.
+func (g Graph) DeleteEdgeContext(ctx context.Context, id string) (res *Response, err error) {
+	g.log("DeleteEdge", id)
+	url := g.getEdgeURL(id)
+	return g.do(ctx, &call{op: "DeleteEdge", method: "DELETE", url: url, id: id})
+}
+
+// errNoKeys is returned by RemoveVertexProperties and
+// RemoveEdgeProperties when no keys are given (which would otherwise
+// delete the whole element).
+var errNoKeys = errors.New("rexster: no property keys to remove")
+
+// RemoveVertexProperties removes the properties with the given keys
+// from the vertex with the given id. The returned response contains
+// the updated vertex, fetched after the properties were removed.
+func (g Graph) RemoveVertexProperties(id string, keys ...string) (res *Response, err error) {
+	return g.RemoveVertexPropertiesContext(context.Background(), id, keys...)
+}
+
+// RemoveVertexPropertiesContext is like RemoveVertexProperties but uses ctx for the HTTP requests.
+func (g Graph) RemoveVertexPropertiesContext(ctx context.Context, id string, keys ...string) (res *Response, err error) {
+	g.log("RemoveVertexProperties", id, keys)
+	if len(keys) == 0 {
+		return nil, errNoKeys
+	}
+	url := g.getVertexURL(id) + "?" + keysQuery(keys)
+	if _, err := g.do(ctx, &call{op: "RemoveVertexProperties", method: "DELETE", url: url, id: id}); err != nil {
+		return nil, err
+	}
+	return g.GetVertexContext(ctx, id)
+}
+
+// RemoveEdgeProperties removes the properties with the given keys
+// from the edge with the given id. The returned response contains the
+// updated edge, fetched after the properties were removed.
+func (g Graph) RemoveEdgeProperties(id string, keys ...string) (res *Response, err error) {
+	return g.RemoveEdgePropertiesContext(context.Background(), id, keys...)
+}
+
+// RemoveEdgePropertiesContext is like RemoveEdgeProperties but uses ctx for the HTTP requests.
+func (g Graph) RemoveEdgePropertiesContext(ctx context.Context, id string, keys ...string) (res *Response, err error) {
+	g.log("RemoveEdgeProperties", id, keys)
+	if len(keys) == 0 {
+		return nil, errNoKeys
+	}
+	url := g.getEdgeURL(id) + "?" + keysQuery(keys)
+	if _, err := g.do(ctx, &call{op: "RemoveEdgeProperties", method: "DELETE", url: url, id: id}); err != nil {
+		return nil, err
+	}
+	return g.GetEdgeContext(ctx, id)
 }
 
 type VertexOrEdge interface {
@@ -143,6 +516,11 @@ type TxAction struct {
 // https://github.com/tinkerpop/rexster/tree/master/rexster-kibbles/batch-kibble.
 // TODO(sqs): support update/delete
 func (g Graph) Batch(actions []TxAction) (res *Response, err error) {
+	return g.BatchContext(context.Background(), actions)
+}
+
+// BatchContext is like Batch but uses ctx for the HTTP request.
+func (g Graph) BatchContext(ctx context.Context, actions []TxAction) (res *Response, err error) {
 	g.log("Batch", len(actions))
 	actionData := make([]map[string]interface{}, len(actions))
 	for i, a := range actions {
@@ -156,7 +534,7 @@ func (g Graph) Batch(actions []TxAction) (res *Response, err error) {
 		actionData[i]["_type"] = a.Item.Type()
 		actionData[i]["_action"] = string(a.Type)
 	}
-	return g.Server.send("POST", g.batchTxUrl(), map[string]interface{}{"tx": actionData})
+	return g.send(ctx, "Batch", "POST", g.batchTxUrl(), map[string]interface{}{"tx": actionData})
 }
 
 type KeyIndexType int
@@ -167,9 +545,14 @@ const (
 )
 
 func (g Graph) CreateKeyIndex(type_ KeyIndexType, key string) (res *Response, err error) {
+	return g.CreateKeyIndexContext(context.Background(), type_, key)
+}
+
+// CreateKeyIndexContext is like CreateKeyIndex but uses ctx for the HTTP request.
+func (g Graph) CreateKeyIndexContext(ctx context.Context, type_ KeyIndexType, key string) (res *Response, err error) {
 	g.log("CreateKeyIndex", key)
 	url := g.getKeyIndexURL(type_, key)
-	return g.Server.send("POST", url, nil)
+	return g.send(ctx, "CreateKeyIndex", "POST", url, nil)
 }
 
 func (g Graph) log(v ...interface{}) {
@@ -180,72 +563,268 @@ func (g Graph) log(v ...interface{}) {
 	}
 }
 
-func (r Rexster) get(url string) (resp *Response, err error) {
-	return r.send("GET", url, nil)
+func (r Rexster) httpClient() *http.Client {
+	if r.HTTPClient != nil {
+		return r.HTTPClient
+	}
+	if r.Transport != nil {
+		return &http.Client{Transport: r.Transport}
+	}
+	if r.TLSConfig != nil {
+		return tlsClient(r.TLSConfig)
+	}
+	return http.DefaultClient
 }
 
-func (r Rexster) send(method string, url string, data map[string]interface{}) (resp *Response, err error) {
-	var body io.Reader
-	if data != nil {
-		buf, err := json.Marshal(data)
+// call is a single operation on a Rexster server.
+type call struct {
+	op     string // operation name (e.g., "GetVertex")
+	graph  string // graph name, if any
+	id     string // vertex or edge ID, if any
+	script string // Gremlin script, if any
+	method string // HTTP method
+	url    string // request URL
+	data   map[string]interface{}
+
+	// decode, if set, is used instead of readResponse to read the
+	// response.
+	decode func(hr *http.Response, method, url string) (*Response, error)
+
+	bytesSent, bytesReceived int64 // totals across all attempts, for Metrics
+
+	span Span // the operation's span (see Tracer)
+}
+
+func (g Graph) get(ctx context.Context, op, url string) (resp *Response, err error) {
+	return g.send(ctx, op, "GET", url, nil)
+}
+
+func (g Graph) send(ctx context.Context, op, method, url string, data map[string]interface{}) (resp *Response, err error) {
+	return g.do(ctx, &call{op: op, method: method, url: url, data: data})
+}
+
+func (g Graph) do(ctx context.Context, c *call) (resp *Response, err error) {
+	if c.method != "GET" && g.Meta != nil && g.Meta.ReadOnly {
+		return nil, ErrReadOnly
+	}
+	c.graph = g.Name
+	return g.Server.send(ctx, c)
+}
+
+// send performs an HTTP request to the Rexster server, waiting for the
+// applicable Limiter, retrying it according to r.Retry, and failing
+// fast if r.Breaker is open. If ctx is canceled or its deadline passes
+// before the request completes, send returns ctx.Err()
+// (context.Canceled or context.DeadlineExceeded).
+func (r Rexster) send(ctx context.Context, c *call) (resp *Response, err error) {
+	start := time.Now()
+	ctx, c.span = r.startSpan(ctx, c)
+	defer func() {
+		c.span.End(err)
+		r.Metrics.record(c, time.Since(start), resp, err)
+	}()
+	var body []byte
+	if c.data != nil {
+		body, err = json.Marshal(c.data)
 		if err != nil {
 			return nil, err
 		}
-		body = bytes.NewReader(buf)
 	}
-	req, err := http.NewRequest(method, url, body)
+	release, err := r.limiter(c).acquire(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if body != nil {
-		req.Header.Add("Content-Type", "application/json")
+	defer release()
+	for attempt := 1; ; attempt++ {
+		probe, allowErr := r.Breaker.allow()
+		if allowErr != nil {
+			return nil, allowErr
+		}
+		resp, err = r.roundTripHosts(ctx, c, body)
+		r.Breaker.record(probe, err)
+		if err == nil || !r.Retry.shouldRetry(ctx, c, attempt, err) {
+			return resp, err
+		}
+		if r.Debug {
+			log.Printf("HTTP %s to %s failed (attempt %d), retrying: %v", c.method, c.url, attempt, err)
+		}
+		if err := r.Retry.wait(ctx, attempt); err != nil {
+			return nil, err
+		}
 	}
+}
 
-	hr, err := http.DefaultClient.Do(req)
+// roundTrip performs a single attempt of an HTTP request to the
+// Rexster server at url (which is c.url, possibly on another host).
+func (r Rexster) roundTrip(ctx context.Context, c *call, url string, body []byte) (resp *Response, err error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	c.span.AddEvent("attempt")
+	req, err := http.NewRequestWithContext(withClientTrace(ctx, c.span), c.method, url, bodyReader)
 	if err != nil {
-		if r.Debug {
-			log.Printf("HTTP %s failed to %s: %v", method, url, err)
-		}
 		return nil, err
 	}
-	resp, errResp := readResponseOrError(hr)
-	if errResp != nil {
-		err = errors.New(strings.TrimSpace(strings.Join([]string{errResp.Message, errResp.Error}, " ")))
-		if r.Debug {
-			log.Printf("HTTP %s failed to %s: %v", method, url, err)
+	if body != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+	if r.Username != "" || r.Password != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+	if r.Authorize != nil {
+		if err := r.Authorize(req); err != nil {
+			return nil, err
 		}
 	}
+
+	start := time.Now()
+	var status int
+	h := r.intercept(func(ic *Call) (*Response, error) {
+		c.bytesSent += int64(len(body))
+		hr, err := r.httpClient().Do(ic.Request)
+		if err != nil {
+			if ctxErr := ic.Request.Context().Err(); ctxErr != nil {
+				err = ctxErr
+			}
+			return nil, err
+		}
+		status = hr.StatusCode
+		hr.Body = countingReader{hr.Body, &c.bytesReceived}
+		decode := readResponse
+		if c.decode != nil {
+			decode = c.decode
+		}
+		c.span.AddEvent("decode_start")
+		defer c.span.AddEvent("decode_done")
+		return decode(hr, ic.Request.Method, ic.Request.URL.String())
+	})
+	ic := &Call{Op: c.op, Graph: c.graph, Request: req}
+	resp, err = h(ic)
+	c.span.SetAttribute("http.url", ic.Request.URL.String())
+	c.span.SetAttribute("http.status_code", status)
+	r.logEvent(ctx, c, ic.Request.URL.String(), status, start, err)
 	return
 }
 
-func readResponseOrError(hr *http.Response) (resp *Response, errResp *errorResponse) {
-	dec := json.NewDecoder(hr.Body)
+// maxBodySnippet is the maximum number of bytes of an undecodable
+// response body kept in Error.Body.
+const maxBodySnippet = 512
+
+// rawResponse is the JSON body of any Rexster response.
+type rawResponse struct {
+	Results   interface{} `json:"results"`
+	Success   *bool       `json:"success"`
+	Version   string      `json:"version"`
+	QueryTime float64     `json:"queryTime"`
+	errorResponse
+}
+
+// readResponse reads and decodes the response to a request with the
+// given method and URL. Any 2xx status is a success (an empty body
+// yields an empty Response). Other statuses, bodies that aren't valid
+// JSON, and responses with "success": false are returned as *Error.
+func readResponse(hr *http.Response, method, url string) (*Response, error) {
 	defer hr.Body.Close()
-	if hr.StatusCode == 200 {
-		resp = new(Response)
-		dec.Decode(resp)
-	} else {
-		errResp = new(errorResponse)
-		dec.Decode(errResp)
+	snippet := &snippetWriter{max: maxBodySnippet}
+	dec := json.NewDecoder(io.TeeReader(hr.Body, snippet))
+	e := &Error{StatusCode: hr.StatusCode, Method: method, URL: url}
+
+	var body rawResponse
+	err := dec.Decode(&body)
+	decodeFailed := err != nil && err != io.EOF
+	if decodeFailed {
+		io.CopyN(snippet, hr.Body, maxBodySnippet)
+		e.Body = snippet.String()
 	}
-	return
+	e.Message, e.ServerError = body.Message, body.Error
+	e.decoded = err == nil
+
+	if hr.StatusCode < 200 || hr.StatusCode >= 300 {
+		return nil, e
+	}
+	if decodeFailed {
+		e.Err = err
+		return nil, e
+	}
+	if body.Success != nil && !*body.Success {
+		e.unsuccessful = true
+		return nil, e
+	}
+	return &Response{
+		Results:   body.Results,
+		Success:   body.Success != nil && *body.Success,
+		Version:   body.Version,
+		QueryTime: body.QueryTime,
+	}, nil
+}
+
+// snippetWriter keeps the first max bytes written to it.
+type snippetWriter struct {
+	buf []byte
+	max int
 }
 
+func (w *snippetWriter) Write(p []byte) (int, error) {
+	if n := w.max - len(w.buf); n > 0 {
+		if n > len(p) {
+			n = len(p)
+		}
+		w.buf = append(w.buf, p[:n]...)
+	}
+	return len(p), nil
+}
+
+func (w *snippetWriter) String() string { return string(w.buf) }
+
 // URLs
 
 func (r Rexster) baseURL() *url.URL {
+	if r.Hosts != nil && len(r.Hosts.URLs) > 0 {
+		return normalizeBaseURL(r.Hosts.URLs[0])
+	}
+	if r.BaseURL != nil {
+		return normalizeBaseURL(r.BaseURL)
+	}
 	return &url.URL{
 		Scheme: "http",
 		Host:   fmt.Sprintf("%s:%d", r.Host, r.RestPort),
 	}
 }
 
+func (r Rexster) rootURL() string {
+	u := r.baseURL()
+	u.Path += "/"
+	return u.String()
+}
+
+func (r Rexster) graphsURL() string {
+	u := r.baseURL()
+	u.Path += "/graphs"
+	return u.String()
+}
+
+// normalizeBaseURL returns a copy of u without a trailing slash,
+// query, or fragment, so that paths can be appended to it.
+func normalizeBaseURL(u *url.URL) *url.URL {
+	c := *u
+	c.Path = strings.TrimSuffix(c.Path, "/")
+	c.RawPath, c.RawQuery, c.Fragment = "", "", ""
+	return &c
+}
+
 func (g Graph) baseURL() *url.URL {
 	u := g.Server.baseURL()
-	u.Path = "/graphs/" + g.Name
+	u.Path += "/graphs/" + g.Name
 	return u
 }
 
+func (g Graph) verticesURL() string {
+	u := g.baseURL()
+	u.Path += "/vertices"
+	return u.String()
+}
+
 func (g Graph) getVertexURL(id string) string {
 	u := g.baseURL()
 	u.Path += "/vertices/"
@@ -275,6 +854,12 @@ func (g Graph) getVertexSubURL(id, subresource string) string {
 	return u + "/" + subresource
 }
 
+func (g Graph) edgesURL() string {
+	u := g.baseURL()
+	u.Path += "/edges"
+	return u.String()
+}
+
 func (g Graph) getEdgeURL(id string) string {
 	u := g.baseURL()
 	u.Path += "/edges/"
@@ -315,6 +900,16 @@ func (g Graph) getKeyIndexURL(type_ KeyIndexType, key string) string {
 	return u.String()
 }
 
+// keysQuery returns a query string that lists keys without values
+// (e.g., "key1&key2"), as Rexster expects for removing properties.
+func keysQuery(keys []string) string {
+	escaped := make([]string, len(keys))
+	for i, k := range keys {
+		escaped[i] = url.QueryEscape(k)
+	}
+	return strings.Join(escaped, "&")
+}
+
 // Go's url.URL.String() improperly(?) redundantly escapes slashes in
 // the path that are already percent-escaped.
 func escapeSlashes(s string) string {
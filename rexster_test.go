@@ -1,8 +1,19 @@
 package rexster_client
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"testing"
 	"time"
 )
@@ -37,6 +48,9 @@ func TestGetVertex(t *testing.T) {
 	if err.Error() != msg {
 		t.Errorf("expected GetVertex to fail with message '%v', got '%v'", msg, err.Error())
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected GetVertex error to match ErrNotFound, got %#v", err)
+	}
 	if r != nil {
 		t.Errorf("expected GetVertex to have nil response (since no such vertex exists), got '%v'", r)
 	}
@@ -159,6 +173,100 @@ func TestGetVertexInE(t *testing.T) {
 	}
 }
 
+func TestGetVertexOut(t *testing.T) {
+	r, err := testG.GetVertexOut("1")
+	if err != nil {
+		t.Fatal("failed to get vertex out:", err)
+	}
+	vs := r.Vertices()
+	if vs == nil {
+		t.Fatal("vertices was nil")
+	}
+	ids := make(map[string]bool)
+	for _, v := range vs {
+		ids[v.Id()] = true
+	}
+	// in the sample graph, marko (1) knows 2 and 4 and created 3
+	for _, id := range []string{"2", "3", "4"} {
+		if !ids[id] {
+			t.Errorf("expected vertex %s to be adjacent to 1, got %v", id, verticesToString(vs))
+		}
+	}
+}
+
+func TestGetVertexIn(t *testing.T) {
+	r, err := testG.GetVertexIn("2")
+	if err != nil {
+		t.Fatal("failed to get vertex in:", err)
+	}
+	if vs := r.Vertices(); vs != nil {
+		want := []*Vertex{
+			&Vertex{Map: map[string]interface{}{"_type": "vertex", "name": "marko", "_id": "1", "age": float64(29)}},
+		}
+		if !verticesEqualsVertices(vs, want) {
+			t.Errorf("want %#v, got %#v", verticesToString(want), verticesToString(vs))
+		}
+	} else {
+		t.Errorf("vertices was nil")
+	}
+}
+
+func TestGetVertexBoth(t *testing.T) {
+	r, err := testG.GetVertexBoth("4")
+	if err != nil {
+		t.Fatal("failed to get vertex both:", err)
+	}
+	// josh (4) is known by 1 and created 3 and 5
+	if vs := r.Vertices(); len(vs) != 3 {
+		t.Errorf("expected 3 vertices adjacent to 4, got %v", verticesToString(vs))
+	}
+}
+
+func TestGetVertexOutE_Options(t *testing.T) {
+	r, err := testG.GetVertexOutE("1", AdjacencyOptions{
+		Labels:     []string{"knows"},
+		Properties: []PropertyFilter{{"weight", Greater, float32(0.5)}},
+		Take:       10,
+	})
+	if err != nil {
+		t.Fatal("failed to get vertex outE:", err)
+	}
+	// of marko's (1) knows edges, only the one to josh (4) has weight > 0.5
+	if es := r.Edges(); len(es) != 1 || es[0].Id() != "8" {
+		t.Errorf("expected only edge 8, got %v", edgesToString(es))
+	}
+}
+
+func TestGetVertexOutCount(t *testing.T) {
+	n, err := testG.GetVertexOutCount("1")
+	if err != nil {
+		t.Fatal("failed to get vertex out count:", err)
+	}
+	if n != 3 {
+		t.Errorf("expected vertex 1 to have 3 out edges, got %d", n)
+	}
+
+	n, err = testG.GetVertexOutCount("1", AdjacencyOptions{Labels: []string{"knows"}})
+	if err != nil {
+		t.Fatal("failed to get vertex out count:", err)
+	}
+	if n != 2 {
+		t.Errorf("expected vertex 1 to have 2 out edges labeled knows, got %d", n)
+	}
+}
+
+func TestGetVertexInIds(t *testing.T) {
+	ids, err := testG.GetVertexInIds("3")
+	if err != nil {
+		t.Fatal("failed to get vertex in ids:", err)
+	}
+	// lop (3) was created by 1, 4, and 6
+	sort.Strings(ids)
+	if want := []string{"1", "4", "6"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("want ids %v, got %v", want, ids)
+	}
+}
+
 func TestGetEdge(t *testing.T) {
 	r, err := testG.GetEdge("12")
 	if err != nil {
@@ -177,6 +285,9 @@ func TestGetEdge(t *testing.T) {
 	if err.Error() != msg {
 		t.Errorf("expected GetEdge to fail with message '%v', got '%v'", msg, err.Error())
 	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected GetEdge error to match ErrNotFound, got %#v", err)
+	}
 }
 
 func TestQueryEdges(t *testing.T) {
@@ -292,6 +403,321 @@ func TestCreateOrUpdateEdge(t *testing.T) {
 	}
 }
 
+func TestCreateVertex(t *testing.T) {
+	v, err := testG.CreateVertex(map[string]interface{}{"color": "blue"})
+	if err != nil {
+		t.Fatal("failed to create vertex:", err)
+	}
+	if v.Id() == "" || v.Get("color") != "blue" {
+		t.Errorf("expected created vertex to have an id and color blue, got %v", v)
+	}
+
+	r, err := testG.GetVertex(v.Id())
+	if err != nil {
+		t.Fatal("failed to get created vertex:", err)
+	}
+	if r.Vertex().Get("color") != "blue" {
+		t.Errorf("expected created vertex to have color blue, got %v", r.Vertex())
+	}
+}
+
+func TestCreateEdge(t *testing.T) {
+	outV, err := testG.CreateVertex(nil)
+	if err != nil {
+		t.Fatal("failed to create out vertex:", err)
+	}
+	inV, err := testG.CreateVertex(nil)
+	if err != nil {
+		t.Fatal("failed to create in vertex:", err)
+	}
+	e, err := testG.CreateEdge(outV.Id(), "TestLabel", inV.Id(), map[string]interface{}{"color": "blue"})
+	if err != nil {
+		t.Fatal("failed to create edge:", err)
+	}
+	if e.Id() == "" || e.Get("_label") != "TestLabel" || e.Get("_outV") != outV.Id() || e.Get("_inV") != inV.Id() || e.Get("color") != "blue" {
+		t.Errorf("unexpected created edge %v", e)
+	}
+}
+
+func TestCreateEdge_Request(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != "POST" || r.URL.Path != "/graphs/testgraph/edges" {
+			t.Errorf("want POST to edges, got %s %s", r.Method, r.URL.Path)
+		}
+		if _, present := body["_id"]; present || body["_outV"] != "1" || body["_label"] != "knows" || body["_inV"] != "2" || body["weight"] != 0.5 {
+			t.Errorf("unexpected request body %v", body)
+		}
+		w.Write([]byte(`{"results":{"_id":"99","_type":"edge","_outV":"1","_label":"knows","_inV":"2","weight":0.5}}`))
+	})
+	props := map[string]interface{}{"weight": 0.5}
+	e, err := g.CreateEdge("1", "knows", "2", props)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Id() != "99" {
+		t.Errorf("want server-assigned id 99, got %v", e.Id())
+	}
+	if len(props) != 1 {
+		t.Errorf("want properties map to be unmodified, got %v", props)
+	}
+}
+
+func TestAllVertices(t *testing.T) {
+	seen := make(map[string]bool)
+	var pages int
+	p := testG.AllVertices(4)
+	for p.Next() {
+		pages++
+		for _, v := range p.Page().Vertices() {
+			if seen[v.Id()] {
+				t.Errorf("vertex %s returned more than once", v.Id())
+			}
+			seen[v.Id()] = true
+		}
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal("failed to get page of vertices:", err)
+	}
+	// the sample graph has vertices 1-6 (and other tests add more)
+	for i := 1; i <= 6; i++ {
+		if id := strconv.Itoa(i); !seen[id] {
+			t.Errorf("expected vertex %s to be returned", id)
+		}
+	}
+	if pages < 2 {
+		t.Errorf("expected multiple pages, got %d", pages)
+	}
+}
+
+func TestAllEdges_Request(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		start, end := r.URL.Query().Get("rexster.offset.start"), r.URL.Query().Get("rexster.offset.end")
+		switch start + "-" + end {
+		case "0-2":
+			w.Write([]byte(`{"results":[{"_id":"a","_type":"edge"},{"_id":"b","_type":"edge"}]}`))
+		case "2-4":
+			w.Write([]byte(`{"results":[{"_id":"c","_type":"edge"}]}`))
+		default:
+			t.Errorf("unexpected page %s-%s", start, end)
+		}
+	})
+	var ids []string
+	p := g.AllEdges(2)
+	for p.Next() {
+		for _, e := range p.Page().Edges() {
+			ids = append(ids, e.Id())
+		}
+	}
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"a", "b", "c"}) {
+		t.Errorf("want edges [a b c], got %v", ids)
+	}
+}
+
+func TestAllVertices_Error(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("rexster.offset.start") == "0" {
+			w.Write([]byte(`{"results":[{"_id":"a","_type":"vertex"},{"_id":"b","_type":"vertex"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	p := g.AllVertices(2)
+	var pages int
+	for p.Next() {
+		pages++
+	}
+	if pages != 1 {
+		t.Errorf("want 1 page before the error, got %d", pages)
+	}
+	if err := p.Err(); !errors.Is(err, ErrServerError) {
+		t.Errorf("want ErrServerError, got %v", err)
+	}
+}
+
+func TestReplaceVertex(t *testing.T) {
+	v := NewVertex(uniqueId("TestReplaceVertex"), map[string]interface{}{"color": "blue", "size": "big"})
+	if _, err := testG.CreateOrUpdateVertex(v); err != nil {
+		t.Fatal("failed to create vertex:", err)
+	}
+
+	v = NewVertex(v.Id(), map[string]interface{}{"color": "red"})
+	r, err := testG.ReplaceVertex(v)
+	if err != nil {
+		t.Fatal("failed to replace vertex:", err)
+	}
+	if got := r.Vertex(); got.Get("color") != "red" {
+		t.Errorf("replaced vertex %v has a different color from what we replaced, %v", got, v)
+	}
+	if _, present := r.Vertex().Map["size"]; present {
+		t.Errorf("expected size to be removed from replaced vertex %v", r.Vertex())
+	}
+}
+
+func TestReplaceEdge(t *testing.T) {
+	outV := NewVertex(uniqueId("TestReplaceEdge_outV"), nil)
+	inV := NewVertex(uniqueId("TestReplaceEdge_inV"), nil)
+	testG.CreateOrUpdateVertex(outV)
+	testG.CreateOrUpdateVertex(inV)
+	e := NewEdge(uniqueId("TestReplaceEdge"), outV.Id(), "TestLabel", inV.Id(), map[string]interface{}{"color": "blue", "size": "big"})
+	if _, err := testG.CreateOrUpdateEdge(e); err != nil {
+		t.Fatal("failed to create edge:", err)
+	}
+
+	e.Map["color"] = "red"
+	delete(e.Map, "size")
+	r, err := testG.ReplaceEdge(e)
+	if err != nil {
+		t.Fatal("failed to replace edge:", err)
+	}
+	if got := r.Edge(); got.Get("color") != "red" {
+		t.Errorf("replaced edge %v has a different color from what we replaced, %v", got, e)
+	}
+	if _, present := r.Edge().Map["size"]; present {
+		t.Errorf("expected size to be removed from replaced edge %v", r.Edge())
+	}
+}
+
+func TestReplaceVertex_Request(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/graphs/testgraph/vertices/1" {
+			t.Errorf("want PUT to vertex 1, got %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex","color":"red"}}`))
+	})
+	if _, err := g.ReplaceVertex(NewVertex("1", map[string]interface{}{"color": "red"})); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteVertex(t *testing.T) {
+	v := NewVertex(uniqueId("TestDeleteVertex"), nil)
+	if _, err := testG.CreateOrUpdateVertex(v); err != nil {
+		t.Fatal("failed to create vertex:", err)
+	}
+
+	if _, err := testG.DeleteVertex(v.Id()); err != nil {
+		t.Fatal("failed to delete vertex:", err)
+	}
+	if r, err := testG.GetVertex(v.Id()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected deleted vertex to not exist, got %v (err %v)", r, err)
+	}
+
+	// try to delete it again
+	if _, err := testG.DeleteVertex(v.Id()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected DeleteVertex of non-existent vertex to fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestDeleteEdge(t *testing.T) {
+	outV := NewVertex(uniqueId("TestDeleteEdge_outV"), nil)
+	inV := NewVertex(uniqueId("TestDeleteEdge_inV"), nil)
+	testG.CreateOrUpdateVertex(outV)
+	testG.CreateOrUpdateVertex(inV)
+	e := NewEdge(uniqueId("TestDeleteEdge"), outV.Id(), "TestLabel", inV.Id(), nil)
+	if _, err := testG.CreateOrUpdateEdge(e); err != nil {
+		t.Fatal("failed to create edge:", err)
+	}
+
+	if _, err := testG.DeleteEdge(e.Id()); err != nil {
+		t.Fatal("failed to delete edge:", err)
+	}
+	if r, err := testG.GetEdge(e.Id()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected deleted edge to not exist, got %v (err %v)", r, err)
+	}
+
+	// try to delete it again
+	if _, err := testG.DeleteEdge(e.Id()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected DeleteEdge of non-existent edge to fail with ErrNotFound, got %v", err)
+	}
+}
+
+func TestRemoveVertexProperties(t *testing.T) {
+	v := NewVertex(uniqueId("TestRemoveVertexProperties"), map[string]interface{}{"color": "blue", "size": "big", "shape": "round"})
+	if _, err := testG.CreateOrUpdateVertex(v); err != nil {
+		t.Fatal("failed to create vertex:", err)
+	}
+
+	r, err := testG.RemoveVertexProperties(v.Id(), "color", "size")
+	if err != nil {
+		t.Fatal("failed to remove vertex properties:", err)
+	}
+	got := r.Vertex()
+	if _, present := got.Map["color"]; present {
+		t.Errorf("expected color to be removed from %v", got)
+	}
+	if _, present := got.Map["size"]; present {
+		t.Errorf("expected size to be removed from %v", got)
+	}
+	if got.Get("shape") != "round" {
+		t.Errorf("expected shape to remain on %v", got)
+	}
+
+	if _, err := testG.RemoveVertexProperties(v.Id()); err == nil {
+		t.Error("expected RemoveVertexProperties with no keys to fail")
+	}
+}
+
+func TestRemoveEdgeProperties(t *testing.T) {
+	outV := NewVertex(uniqueId("TestRemoveEdgeProperties_outV"), nil)
+	inV := NewVertex(uniqueId("TestRemoveEdgeProperties_inV"), nil)
+	testG.CreateOrUpdateVertex(outV)
+	testG.CreateOrUpdateVertex(inV)
+	e := NewEdge(uniqueId("TestRemoveEdgeProperties"), outV.Id(), "TestLabel", inV.Id(), map[string]interface{}{"color": "blue", "shape": "round"})
+	if _, err := testG.CreateOrUpdateEdge(e); err != nil {
+		t.Fatal("failed to create edge:", err)
+	}
+
+	r, err := testG.RemoveEdgeProperties(e.Id(), "color")
+	if err != nil {
+		t.Fatal("failed to remove edge properties:", err)
+	}
+	got := r.Edge()
+	if _, present := got.Map["color"]; present {
+		t.Errorf("expected color to be removed from %v", got)
+	}
+	if got.Get("shape") != "round" {
+		t.Errorf("expected shape to remain on %v", got)
+	}
+}
+
+func TestRemoveVertexProperties_Request(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "DELETE":
+			if want := "a+b&c"; r.URL.RawQuery != want {
+				t.Errorf("want query %q, got %q", want, r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"version":"2.4.0"}`))
+		case "GET":
+			w.Write([]byte(`{"results":{"_id":"1","_type":"vertex","d":"x"}}`))
+		}
+	})
+	r, err := g.RemoveVertexProperties("1", "a b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := r.Vertex(); v == nil || v.Get("d") != "x" {
+		t.Errorf("want updated vertex, got %v", r)
+	}
+}
+
+func TestDeleteVertex_Request(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if want := "/graphs/testgraph/vertices/has%252Fa%252Fslash"; r.Method != "DELETE" || r.URL.EscapedPath() != want {
+			t.Errorf("want DELETE %s, got %s %s", want, r.Method, r.URL.EscapedPath())
+		}
+		w.Write([]byte(`{"version":"2.4.0","queryTime":1.2}`))
+	})
+	if _, err := g.DeleteVertex("has/a/slash"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestEval(t *testing.T) {
 	r, err := testG.Eval("g.V[3]")
 	if err != nil {
@@ -306,6 +732,9 @@ func TestEval(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected Eval to fail, got resp:", r)
 	}
+	if !errors.Is(err, ErrScriptFailed) {
+		t.Errorf("expected Eval error to match ErrScriptFailed, got %#v", err)
+	}
 }
 
 func TestBatch(t *testing.T) {
@@ -351,3 +780,231 @@ func TestCreateKeyIndex(t *testing.T) {
 func uniqueId(prefix string) string {
 	return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
 }
+
+// newTestGraph returns a Graph served by an httptest server that
+// handles all requests with h. Unlike testG, it does not require a
+// running Rexster server.
+func newTestGraph(t *testing.T, h http.HandlerFunc) Graph {
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.ParseUint(u.Port(), 10, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Graph{
+		Name:   "testgraph",
+		Server: Rexster{Host: u.Hostname(), RestPort: uint16(port)},
+	}
+}
+
+func TestGetVertexContext_Deadline(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r, err := g.GetVertexContext(ctx, "1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("want context.DeadlineExceeded, got %v (resp %v)", err, r)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestRexster_Transport(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	})
+	var called bool
+	g.Server.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	r, err := g.GetVertex("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("custom Transport was not used")
+	}
+	if v := r.Vertex(); v == nil || v.Id() != "1" {
+		t.Errorf("want vertex 1, got %v", r)
+	}
+}
+
+func TestGetVertexURL_BaseURL(t *testing.T) {
+	g := Graph{
+		Name:   "tinkergraph",
+		Server: Rexster{BaseURL: &url.URL{Scheme: "https", Host: "example.com:8443", Path: "/rexster/"}},
+	}
+	u := g.getVertexURL("has/a/slash")
+	wantUrl := "https://example.com:8443/rexster/graphs/tinkergraph/vertices/has%252Fa%252Fslash"
+	if u != wantUrl {
+		t.Errorf("want %s, got %s", wantUrl, u)
+	}
+}
+
+func TestRexster_TLSConfig(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rexster/graphs/testgraph/vertices/1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	}))
+	defer s.Close()
+	u, _ := url.Parse(s.URL + "/rexster")
+	pool := x509.NewCertPool()
+	pool.AddCert(s.Certificate())
+	g := Graph{
+		Name:   "testgraph",
+		Server: Rexster{BaseURL: u, TLSConfig: &tls.Config{RootCAs: pool}},
+	}
+	if _, err := g.GetVertex("1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRexster_Auth(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		if user != "alice" || pass != "secret" || r.Header.Get("X-Token") != "t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	})
+
+	if _, err := g.GetVertex("1"); !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("want ErrAuthFailed without credentials, got %v", err)
+	}
+
+	g.Server.Username, g.Server.Password = "alice", "secret"
+	g.Server.Authorize = func(req *http.Request) error {
+		req.Header.Set("X-Token", "t")
+		return nil
+	}
+	if _, err := g.GetVertex("1"); err != nil {
+		t.Errorf("want no error with credentials, got %v", err)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestReadResponse_ReadError(t *testing.T) {
+	hr := &http.Response{StatusCode: 200, Body: io.NopCloser(errReader{io.ErrUnexpectedEOF})}
+	r, err := readResponse(hr, "GET", "http://x/vertices/1")
+	if !errors.Is(err, ErrInvalidResponse) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("want ErrInvalidResponse wrapping the read error, got %v", err)
+	}
+	if r != nil {
+		t.Errorf("want nil response, got %v", r)
+	}
+}
+
+func TestReadResponse(t *testing.T) {
+	tests := []struct {
+		status     int
+		body       string
+		wantErr    error // nil for success
+		wantMsg    string
+		notWantErr error
+	}{
+		{200, `{"results":{"_id":"1"},"version":"2.4.0"}`, nil, "", nil},
+		{201, `{"results":{"_id":"1"}}`, nil, "", nil},
+		{204, ``, nil, "", nil},
+		{200, `{"success":true}`, nil, "", nil},
+		{200, `{"success":false,"message":"oops"}`, ErrScriptFailed, "oops", nil},
+		{200, `{"results":[`, ErrInvalidResponse, "", nil},
+		{200, `<html>hi</html>`, ErrInvalidResponse, "", nil},
+		{502, `<html>Bad Gateway</html>`, ErrServerError, "rexster: HTTP GET http://x/tp/gremlin: 502 Bad Gateway: <html>Bad Gateway</html>", ErrScriptFailed},
+		{500, `{"message":"","error":"javax.script.ScriptException"}`, ErrScriptFailed, "javax.script.ScriptException", nil},
+		{404, `{"message":"not here"}`, ErrNotFound, "not here", ErrScriptFailed},
+	}
+	for _, test := range tests {
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(test.status)
+		rec.WriteString(test.body)
+		r, err := readResponse(rec.Result(), "GET", "http://x/tp/gremlin")
+		if test.wantErr == nil {
+			if err != nil || r == nil {
+				t.Errorf("%d %s: want success, got %v", test.status, test.body, err)
+			}
+			continue
+		}
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%d %s: want error matching %v, got %v", test.status, test.body, test.wantErr, err)
+			continue
+		}
+		if test.notWantErr != nil && errors.Is(err, test.notWantErr) {
+			t.Errorf("%d %s: want error to not match %v", test.status, test.body, test.notWantErr)
+		}
+		if test.wantMsg != "" && err.Error() != test.wantMsg {
+			t.Errorf("%d %s: want message %q, got %q", test.status, test.body, test.wantMsg, err.Error())
+		}
+		if r != nil {
+			t.Errorf("%d %s: want nil response, got %v", test.status, test.body, r)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	if err := testG.Server.Ping(); err != nil {
+		t.Fatal("failed to ping:", err)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	info, err := testG.Server.Info()
+	if err != nil {
+		t.Fatal("failed to get server info:", err)
+	}
+	if info.Version == "" || info.UpTime == "" {
+		t.Errorf("expected server version and uptime, got %+v", info)
+	}
+}
+
+func TestGraphs(t *testing.T) {
+	graphs, err := testG.Server.Graphs()
+	if err != nil {
+		t.Fatal("failed to list graphs:", err)
+	}
+	var found bool
+	for _, g := range graphs {
+		if g == testG.Name {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected graphs %v to include %s", graphs, testG.Name)
+	}
+}
+
+func TestGraphInfo(t *testing.T) {
+	info, err := testG.Info()
+	if err != nil {
+		t.Fatal("failed to get graph info:", err)
+	}
+	if info.Name != testG.Name || info.ReadOnly {
+		t.Errorf("expected writable graph named %s, got %+v", testG.Name, info)
+	}
+	if !info.Features.Has(FeatureSupportsVertexIteration) {
+		t.Errorf("expected tinkergraph to support vertex iteration, got %v", info.Features)
+	}
+	var gremlin bool
+	for _, e := range info.Extensions {
+		if e.Namespace == "tp" && e.Name == "gremlin" {
+			gremlin = true
+		}
+	}
+	if !gremlin {
+		t.Errorf("expected tp:gremlin extension, got %+v", info.Extensions)
+	}
+}
@@ -0,0 +1,82 @@
+package rexster_client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+// ServerInfo describes a Rexster server (from GET /).
+type ServerInfo struct {
+	Name    string   `json:"name"`    // server name (e.g., "Rexster: A Graph Server")
+	Version string   `json:"version"` // Rexster version
+	UpTime  string   `json:"upTime"`  // uptime (e.g., "0[d]:01[h]:02[m]:03[s]")
+	Graphs  []string `json:"graphs"`  // names of the graphs served
+}
+
+// Ping checks that the Rexster server is up and responding.
+func (r Rexster) Ping() error {
+	return r.PingContext(context.Background())
+}
+
+// PingContext is like Ping but uses ctx for the HTTP request.
+func (r Rexster) PingContext(ctx context.Context) error {
+	_, err := r.send(ctx, &call{op: "Ping", method: "GET", url: r.rootURL()})
+	return err
+}
+
+// Info returns information about the Rexster server.
+func (r Rexster) Info() (*ServerInfo, error) {
+	return r.InfoContext(context.Background())
+}
+
+// InfoContext is like Info but uses ctx for the HTTP request.
+func (r Rexster) InfoContext(ctx context.Context) (*ServerInfo, error) {
+	info := new(ServerInfo)
+	_, err := r.send(ctx, &call{op: "Info", method: "GET", url: r.rootURL(), decode: decodeInto(info)})
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// Graphs returns the names of the graphs served by the Rexster server.
+func (r Rexster) Graphs() ([]string, error) {
+	return r.GraphsContext(context.Background())
+}
+
+// GraphsContext is like Graphs but uses ctx for the HTTP request.
+func (r Rexster) GraphsContext(ctx context.Context) ([]string, error) {
+	var v struct {
+		Graphs []string `json:"graphs"`
+	}
+	_, err := r.send(ctx, &call{op: "Graphs", method: "GET", url: r.graphsURL(), decode: decodeInto(&v)})
+	if err != nil {
+		return nil, err
+	}
+	return v.Graphs, nil
+}
+
+// decodeInto returns a call decoder that, in addition to reading the
+// Response as readResponse does, decodes a successful response's body
+// into v. It is meant for small responses that don't have the usual
+// "results" field.
+func decodeInto(v interface{}) func(hr *http.Response, method, url string) (*Response, error) {
+	return func(hr *http.Response, method, url string) (*Response, error) {
+		var buf bytes.Buffer
+		hr.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.TeeReader(hr.Body, &buf), hr.Body}
+		resp, err := readResponse(hr, method, url)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.NewDecoder(&buf).Decode(v); err != nil && err != io.EOF {
+			return nil, &Error{StatusCode: hr.StatusCode, Method: method, URL: url, Err: err}
+		}
+		return resp, nil
+	}
+}
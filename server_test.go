@@ -0,0 +1,39 @@
+package rexster_client
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestServerInfo_Fake(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{"name":"Rexster: A Graph Server","graphs":["a","b"],"queryTime":0.1,"upTime":"0[d]:00[h]:01[m]:02[s]","version":"2.4.0"}`))
+		case "/graphs":
+			w.Write([]byte(`{"version":"2.4.0","name":"Rexster: A Graph Server","graphs":["a","b"],"queryTime":0.1,"upTime":"0[d]:00[h]:01[m]:02[s]"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	if err := g.Server.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	info, err := g.Server.Info()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &ServerInfo{Name: "Rexster: A Graph Server", Version: "2.4.0", UpTime: "0[d]:00[h]:01[m]:02[s]", Graphs: []string{"a", "b"}}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("want %+v, got %+v", want, info)
+	}
+	graphs, err := g.Server.Graphs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(graphs, []string{"a", "b"}) {
+		t.Errorf("want graphs [a b], got %v", graphs)
+	}
+}
@@ -0,0 +1,201 @@
+package rexster_client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// QueryVerticesStream is like QueryVertices, but instead of decoding
+// all of the vertices into the Response's Results (which is nil), it
+// decodes them one at a time and calls fn with each. Memory use does
+// not grow with the number of results. If fn returns an error,
+// decoding stops and QueryVerticesStream returns that error.
+func (g Graph) QueryVerticesStream(key, value string, fn func(v *Vertex) error) (res *Response, err error) {
+	return g.QueryVerticesStreamContext(context.Background(), key, value, fn)
+}
+
+// QueryVerticesStreamContext is like QueryVerticesStream but uses ctx for the HTTP request.
+func (g Graph) QueryVerticesStreamContext(ctx context.Context, key, value string, fn func(v *Vertex) error) (res *Response, err error) {
+	g.log("QueryVerticesStream", key, value)
+	url := g.queryVerticesURL(key, value)
+	return g.stream(ctx, &call{op: "QueryVerticesStream", url: url}, func(raw json.RawMessage) error {
+		m, err := decodeElement(raw, "vertex")
+		if err != nil {
+			return err
+		}
+		return fn(&Vertex{m})
+	})
+}
+
+// QueryEdgesStream is like QueryEdges, but it decodes the edges one at
+// a time and calls fn with each. See QueryVerticesStream.
+func (g Graph) QueryEdgesStream(key, value string, fn func(e *Edge) error) (res *Response, err error) {
+	return g.QueryEdgesStreamContext(context.Background(), key, value, fn)
+}
+
+// QueryEdgesStreamContext is like QueryEdgesStream but uses ctx for the HTTP request.
+func (g Graph) QueryEdgesStreamContext(ctx context.Context, key, value string, fn func(e *Edge) error) (res *Response, err error) {
+	g.log("QueryEdgesStream", key, value)
+	url := g.queryEdgesURL(key, value)
+	return g.stream(ctx, &call{op: "QueryEdgesStream", url: url}, func(raw json.RawMessage) error {
+		m, err := decodeElement(raw, "edge")
+		if err != nil {
+			return err
+		}
+		return fn(&Edge{m})
+	})
+}
+
+// EvalStream is like Eval, but it calls fn with the raw JSON of each
+// element of the script's results as it is read. If the result is a
+// scalar, fn is called once with it. See
+// QueryVerticesStream.
+func (g Graph) EvalStream(script string, fn func(raw json.RawMessage) error) (res *Response, err error) {
+	return g.EvalStreamContext(context.Background(), script, fn)
+}
+
+// EvalStreamContext is like EvalStream but uses ctx for the HTTP request.
+func (g Graph) EvalStreamContext(ctx context.Context, script string, fn func(raw json.RawMessage) error) (res *Response, err error) {
+	g.log("EvalStream", script)
+	url := g.evalURL(script)
+	return g.stream(ctx, &call{op: "EvalStream", url: url, script: script}, fn)
+}
+
+// errWrongType is returned by the *Stream methods when a result is
+// not of the expected element type.
+var errWrongType = errors.New("rexster: result has unexpected _type")
+
+func decodeElement(raw json.RawMessage, typ string) (map[string]interface{}, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	if m["_type"] != typ {
+		return nil, errWrongType
+	}
+	return m, nil
+}
+
+// stream sends c as a GET request and calls fn with each element of
+// the response's results.
+func (g Graph) stream(ctx context.Context, c *call, fn func(raw json.RawMessage) error) (res *Response, err error) {
+	var fnErr error
+	c.method = "GET"
+	c.decode = func(hr *http.Response, method, url string) (resp *Response, err error) {
+		resp, fnErr, err = readResponseStream(hr, method, url, fn)
+		return resp, err
+	}
+	res, err = g.do(ctx, c)
+	if fnErr != nil {
+		return nil, fnErr
+	}
+	return res, err
+}
+
+// readResponseStream is like readResponse, but it calls fn with each
+// element of the results instead of storing them in the Response. If
+// fn returns an error, reading stops and the error is returned as
+// fnErr, along with a Response holding the fields decoded so far (and
+// err is nil, since the response itself was fine).
+func readResponseStream(hr *http.Response, method, url string, fn func(raw json.RawMessage) error) (resp *Response, fnErr, err error) {
+	if hr.StatusCode < 200 || hr.StatusCode >= 300 {
+		resp, err = readResponse(hr, method, url)
+		return resp, nil, err
+	}
+	defer hr.Body.Close()
+	e := &Error{StatusCode: hr.StatusCode, Method: method, URL: url}
+	fail := func(err error) (*Response, error, error) {
+		e.Err = err
+		return nil, nil, e
+	}
+
+	dec := json.NewDecoder(hr.Body)
+	dec.UseNumber() // so scalar results are passed to fn verbatim
+	tok, err := dec.Token()
+	if err == io.EOF {
+		return new(Response), nil, nil
+	} else if err != nil {
+		return fail(err)
+	}
+	if tok != json.Delim('{') {
+		return fail(errors.New("response is not a JSON object"))
+	}
+	var body rawResponse
+	response := func() *Response {
+		return &Response{
+			Success:   body.Success != nil && *body.Success,
+			Version:   body.Version,
+			QueryTime: body.QueryTime,
+		}
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fail(err)
+		}
+		switch tok {
+		case "results":
+			fnErr, err = streamResults(dec, fn)
+			if fnErr != nil {
+				return response(), fnErr, nil
+			}
+		case "success":
+			err = dec.Decode(&body.Success)
+		case "version":
+			err = dec.Decode(&body.Version)
+		case "queryTime":
+			err = dec.Decode(&body.QueryTime)
+		case "message":
+			err = dec.Decode(&body.Message)
+		case "error":
+			err = dec.Decode(&body.Error)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return fail(err)
+		}
+	}
+	if body.Success != nil && !*body.Success {
+		e.Message, e.ServerError = body.Message, body.Error
+		return nil, nil, e
+	}
+	return response(), nil, nil
+}
+
+// streamResults reads the value of "results" from dec, calling fn with
+// each element if it's an array or with the value if it's a scalar.
+func streamResults(dec *json.Decoder, fn func(raw json.RawMessage) error) (fnErr, err error) {
+	var raw json.RawMessage
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != json.Delim('[') {
+		if tok == nil {
+			return nil, nil
+		}
+		if _, ok := tok.(json.Delim); ok {
+			// Rexster only returns single elements (objects) from
+			// endpoints that aren't streamed.
+			return nil, errors.New("results is not an array or scalar")
+		}
+		raw, _ = json.Marshal(tok)
+		return fn(raw), nil
+	}
+	for dec.More() {
+		raw = nil
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		if err := fn(raw); err != nil {
+			return err, nil
+		}
+	}
+	_, err = dec.Token() // closing ]
+	return nil, err
+}
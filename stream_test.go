@@ -0,0 +1,100 @@
+package rexster_client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestQueryVerticesStream(t *testing.T) {
+	const n = 1000
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"version":"2.4.0","results":[`)
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"_id":"%d","_type":"vertex","name":"v%d"}`, i, i)
+		}
+		fmt.Fprint(w, `],"totalSize":1000,"queryTime":1.5}`)
+	})
+
+	var count int
+	r, err := g.QueryVerticesStream("lang", "java", func(v *Vertex) error {
+		if want := fmt.Sprint(count); v.Id() != want {
+			t.Errorf("want vertex %s, got %s", want, v.Id())
+		}
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != n {
+		t.Errorf("want %d vertices, got %d", n, count)
+	}
+	if r.Version != "2.4.0" || r.QueryTime != 1.5 || r.Results != nil {
+		t.Errorf("unexpected response %+v", r)
+	}
+
+	// stop early; interceptors still see a response
+	stop := errors.New("stop")
+	count = 0
+	g.Server.Interceptors = []Interceptor{func(c *Call, next Handler) (*Response, error) {
+		resp, err := next(c)
+		if err == nil && resp == nil {
+			t.Error("want non-nil response from next when err is nil")
+		}
+		return resp, err
+	}}
+	_, err = g.QueryVerticesStream("lang", "java", func(v *Vertex) error {
+		count++
+		return stop
+	})
+	if err != stop || count != 1 {
+		t.Errorf("want stop error after 1 vertex, got %v after %d", err, count)
+	}
+	g.Server.Interceptors = nil
+
+	// wrong type
+	_, err = g.QueryEdgesStream("lang", "java", func(e *Edge) error { return nil })
+	if err != errWrongType {
+		t.Errorf("want errWrongType, got %v", err)
+	}
+}
+
+func TestEvalStream(t *testing.T) {
+	tests := map[string][]string{
+		`{"success":true,"results":[1,"a",{"x":2}]}`:      {`1`, `"a"`, `{"x":2}`},
+		`{"success":true,"results":12345678901234567890}`: {`12345678901234567890`},
+		`{"success":true,"results":null}`:                 nil,
+	}
+	for body, want := range tests {
+		g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		})
+		var got []string
+		_, err := g.EvalStream("g.V", func(raw json.RawMessage) error {
+			got = append(got, string(raw))
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: %v", body, err)
+		}
+		if strings.Join(got, " ") != strings.Join(want, " ") {
+			t.Errorf("%s: want %v, got %v", body, want, got)
+		}
+	}
+
+	// truncated body
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"results":[1,2`))
+	})
+	_, err := g.EvalStream("g.V", func(raw json.RawMessage) error { return nil })
+	if !errors.Is(err, ErrInvalidResponse) {
+		t.Errorf("want ErrInvalidResponse, got %v", err)
+	}
+}
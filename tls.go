@@ -0,0 +1,53 @@
+package rexster_client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// NewTLSConfig returns a TLS configuration for connecting to a Rexster
+// server over HTTPS. If caFile is non-empty, the server certificate is
+// verified against the PEM-encoded CA certificates in caFile instead of
+// the system pool. If certFile and keyFile are non-empty, the client
+// presents the certificate they contain.
+func NewTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	c := new(tls.Config)
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		c.RootCAs = x509.NewCertPool()
+		if !c.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("rexster: no CA certificates found in " + caFile)
+		}
+	}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		c.Certificates = []tls.Certificate{cert}
+	}
+	return c, nil
+}
+
+// tlsClients holds one *http.Client per *tls.Config so that
+// connections are reused across requests (Rexster is passed by value,
+// so it can't hold the client itself). Entries are never removed, which
+// is why Rexster.TLSConfig is documented as long-lived and shared.
+var tlsClients sync.Map
+
+func tlsClient(c *tls.Config) *http.Client {
+	if hc, ok := tlsClients.Load(c); ok {
+		return hc.(*http.Client)
+	}
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = c
+	hc, _ := tlsClients.LoadOrStore(c, &http.Client{Transport: t})
+	return hc.(*http.Client)
+}
@@ -0,0 +1,93 @@
+package rexster_client
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"net/http/httptrace"
+)
+
+// A Tracer starts a Span for each operation on a Rexster server (e.g.,
+// GetVertex or Eval). Set Rexster.Tracer to use one; adapt it to your
+// tracing system (e.g., OpenTelemetry) by implementing this interface.
+//
+// Span attributes set by this package are:
+//
+//	rexster.op            operation name (e.g., "GetVertex")
+//	rexster.graph         graph name
+//	rexster.id            vertex or edge ID
+//	rexster.script_sha256 SHA-256 of the Gremlin script (hex)
+//	http.method           HTTP method
+//	http.url              URL of the last attempt
+//	http.status_code      HTTP status code of the last attempt
+//
+// Span events are added for each attempt ("attempt"), from
+// net/http/httptrace ("dns_start", "dns_done", "connect_start",
+// "connect_done", "tls_handshake_start", "tls_handshake_done",
+// "got_conn", "wrote_request", "got_first_response_byte"), and around
+// decoding the response ("decode_start", "decode_done").
+type Tracer interface {
+	StartSpan(ctx context.Context, op string) (context.Context, Span)
+}
+
+// A Span is a traced operation started by a Tracer.
+//
+// Implementations must be safe for concurrent use: the httptrace events
+// are added from net/http's goroutines (e.g., "connect_start" and
+// "connect_done" from concurrent dials to each of a host's addresses),
+// possibly while SetAttribute is called for the operation.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	AddEvent(name string)
+
+	// End ends the span. The err is the operation's error, if any.
+	End(err error)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) AddEvent(name string)                       {}
+func (noopSpan) End(err error)                              {}
+
+// startSpan starts a span for c using r.Tracer, or returns a no-op
+// span if r.Tracer is nil.
+func (r Rexster) startSpan(ctx context.Context, c *call) (context.Context, Span) {
+	if r.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+	ctx, span := r.Tracer.StartSpan(ctx, c.op)
+	span.SetAttribute("rexster.op", c.op)
+	if c.graph != "" {
+		span.SetAttribute("rexster.graph", c.graph)
+	}
+	if c.id != "" {
+		span.SetAttribute("rexster.id", c.id)
+	}
+	if c.script != "" {
+		sum := sha256.Sum256([]byte(c.script))
+		span.SetAttribute("rexster.script_sha256", hex.EncodeToString(sum[:]))
+	}
+	span.SetAttribute("http.method", c.method)
+	return ctx, span
+}
+
+// withClientTrace returns a context that reports net/http/httptrace
+// events to span.
+func withClientTrace(ctx context.Context, span Span) context.Context {
+	if _, ok := span.(noopSpan); ok {
+		return ctx
+	}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { span.AddEvent("dns_start") },
+		DNSDone:              func(httptrace.DNSDoneInfo) { span.AddEvent("dns_done") },
+		ConnectStart:         func(string, string) { span.AddEvent("connect_start") },
+		ConnectDone:          func(string, string, error) { span.AddEvent("connect_done") },
+		TLSHandshakeStart:    func() { span.AddEvent("tls_handshake_start") },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { span.AddEvent("tls_handshake_done") },
+		GotConn:              func(httptrace.GotConnInfo) { span.AddEvent("got_conn") },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { span.AddEvent("wrote_request") },
+		GotFirstResponseByte: func() { span.AddEvent("got_first_response_byte") },
+	})
+}
@@ -0,0 +1,88 @@
+package rexster_client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type testSpan struct {
+	mu     sync.Mutex
+	op     string
+	attrs  map[string]interface{}
+	events []string
+	ended  bool
+	err    error
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs[key] = value
+}
+
+func (s *testSpan) AddEvent(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, name)
+}
+
+func (s *testSpan) End(err error) { s.ended, s.err = true, err }
+
+type testTracer struct{ spans []*testSpan }
+
+func (t *testTracer) StartSpan(ctx context.Context, op string) (context.Context, Span) {
+	s := &testSpan{op: op, attrs: make(map[string]interface{})}
+	t.spans = append(t.spans, s)
+	return ctx, s
+}
+
+func TestTracer(t *testing.T) {
+	g := newTestGraph(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/tp/gremlin") {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"","error":"javax.script.ScriptException"}`))
+			return
+		}
+		w.Write([]byte(`{"results":{"_id":"1","_type":"vertex"}}`))
+	})
+	tr := new(testTracer)
+	g.Server.Tracer = tr
+
+	if _, err := g.GetVertex("1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Eval("g.V"); !errors.Is(err, ErrScriptFailed) {
+		t.Fatalf("want ErrScriptFailed, got %v", err)
+	}
+	if len(tr.spans) != 2 {
+		t.Fatalf("want 2 spans, got %d", len(tr.spans))
+	}
+
+	s := tr.spans[0]
+	if s.op != "GetVertex" || !s.ended || s.err != nil {
+		t.Errorf("unexpected span %+v", s)
+	}
+	for k, v := range map[string]interface{}{"rexster.op": "GetVertex", "rexster.graph": "testgraph", "rexster.id": "1", "http.method": "GET", "http.status_code": 200} {
+		if s.attrs[k] != v {
+			t.Errorf("want attribute %s=%v, got %v", k, v, s.attrs[k])
+		}
+	}
+	events := strings.Join(s.events, " ")
+	for _, want := range []string{"attempt", "got_conn", "wrote_request", "got_first_response_byte", "decode_start", "decode_done"} {
+		if !strings.Contains(events, want) {
+			t.Errorf("want event %s, got %v", want, events)
+		}
+	}
+
+	s = tr.spans[1]
+	if h, _ := s.attrs["rexster.script_sha256"].(string); len(h) != 64 {
+		t.Errorf("want script hash, got %v", s.attrs)
+	}
+	if !errors.Is(s.err, ErrScriptFailed) {
+		t.Errorf("want span to end with ErrScriptFailed, got %v", s.err)
+	}
+}